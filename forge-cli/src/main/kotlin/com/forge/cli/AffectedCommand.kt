@@ -0,0 +1,136 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.affected.AffectedProjects
+import com.forge.affected.AffectedProjectsCalculator
+import com.forge.affected.AffectedTask
+import com.forge.core.ProjectGraph
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.multiple
+import com.github.ajalt.clikt.parameters.options.option
+
+/**
+ * Show projects affected by changes since a git base ref
+ */
+class AffectedCommand : CliktCommand("affected") {
+    override fun help(context: Context): String = "Show projects affected by changes since a base ref"
+    private val base by option("--base", help = "Base ref to compare against (defaults to affected.defaultBase)")
+    private val targetName by option("--target", help = "Only show projects that have this target")
+    private val showCached by option("--show-cached", help = "Mark tasks whose inputs did not change as cache hits (requires --target)").flag()
+    private val myTeam by option("--my-team", help = "Only show projects tagged team:<name> for FORGE_TEAM or affected.team").flag()
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        requireTargetForShowCached(showCached, targetName)
+        val workspaceRoot = findWorkspaceRoot()
+        val (projectGraph, workspaceConfig) = discoverProjectsWithConfig(workspaceRoot)
+        val baseRef = base ?: workspaceConfig?.affected?.defaultBase ?: "main"
+
+        val team = if (myTeam) System.getenv("FORGE_TEAM")?.takeIf { it.isNotBlank() } ?: workspaceConfig?.affected?.team else null
+        if (myTeam && team == null) {
+            echo("❌ --my-team needs a team: set FORGE_TEAM or affected.team in forge.json", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        val calculator = AffectedProjectsCalculator(workspaceRoot, projectGraph)
+        val affected = try {
+            calculator.affectedSince(baseRef)
+        } catch (e: IllegalStateException) {
+            echo("❌ ${e.message}", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }.let { if (team != null) calculator.ownedBy(it, team) else it }
+
+        val description = if (team != null) "since '$baseRef' owned by team '$team'" else "since '$baseRef'"
+        printAffected(affected, projectGraph, targetName, json, description,
+            if (showCached) calculator.affectedTasks(affected, targetName!!) else null)
+    }
+}
+
+/**
+ * Show projects that would be affected if the given files changed, without consulting git
+ */
+class WhatIfCommand : CliktCommand("what-if") {
+    override fun help(context: Context): String = "Show projects affected by a hypothetical file change"
+    private val changed by option("--changed", help = "Workspace-relative path of a changed file (repeatable)").multiple(required = true)
+    private val targetName by option("--target", help = "Only show projects that have this target")
+    private val showCached by option("--show-cached", help = "Mark tasks whose inputs would not change as cache hits (requires --target)").flag()
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        requireTargetForShowCached(showCached, targetName)
+        val workspaceRoot = findWorkspaceRoot()
+        val projectGraph = discoverProjects(workspaceRoot)
+
+        val calculator = AffectedProjectsCalculator(workspaceRoot, projectGraph)
+        val affected = calculator.affectedByFiles(changed)
+
+        printAffected(affected, projectGraph, targetName, json, "if ${changed.size} file(s) changed",
+            if (showCached) calculator.affectedTasks(affected, targetName!!) else null)
+    }
+}
+
+private fun CliktCommand.requireTargetForShowCached(showCached: Boolean, targetName: String?) {
+    if (showCached && targetName == null) {
+        echo("❌ --show-cached requires --target", err = true)
+        throw com.github.ajalt.clikt.core.Abort()
+    }
+}
+
+private fun CliktCommand.printAffected(
+    affected: AffectedProjects,
+    projectGraph: ProjectGraph,
+    targetName: String?,
+    json: Boolean,
+    description: String,
+    tasks: List<AffectedTask>? = null
+) {
+    val projects = affected.affected.filter { project ->
+        targetName == null || projectGraph.getProject(project)?.data?.hasTarget(targetName) == true
+    }
+    val tasksById = tasks?.associateBy { it.taskId }
+
+    if (json) {
+        val output = mutableMapOf<String, Any>(
+            "changedFiles" to affected.changedFiles,
+            "directlyAffected" to affected.directlyAffected,
+            "projects" to projects,
+            "tasks" to (targetName?.let { target -> projects.map { "$it:$target" } } ?: emptyList<String>())
+        )
+        if (tasks != null) {
+            output["cachedTasks"] = tasks.filter { it.wouldBeCached }.map { it.taskId }
+            output["tasksToRun"] = tasks.filterNot { it.wouldBeCached }.map { it.taskId }
+        }
+        echo(ObjectMapper()
+            .writerWithDefaultPrettyPrinter()
+            .writeValueAsString(output))
+        return
+    }
+
+    if (projects.isEmpty()) {
+        echo("✅ No projects affected $description")
+        return
+    }
+
+    echo("🎯 Affected projects $description (${projects.size}):")
+    echo("═".repeat(40))
+    projects.forEach { project ->
+        val marker = if (project in affected.directlyAffected) "changed" else "dependent"
+        val label = targetName?.let { "$project:$it" } ?: project
+        val cacheNote = tasksById?.get(label)?.let { task ->
+            when {
+                task.wouldBeCached -> " ⚡ cache hit, no inputs changed"
+                !task.cacheable -> " ▶️  runs, not cacheable"
+                else -> " ▶️  runs, ${task.changedInputs.size} input(s) changed"
+            }
+        } ?: ""
+        echo("  • $label ($marker)$cacheNote")
+    }
+
+    if (tasks != null) {
+        val toRun = tasks.count { !it.wouldBeCached }
+        echo()
+        echo("📊 $toRun task(s) would run, ${tasks.size - toRun} would be cache hits")
+    }
+}
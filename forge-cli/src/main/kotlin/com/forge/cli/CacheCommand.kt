@@ -0,0 +1,110 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.cache.InputSnapshotDiff
+import com.forge.cache.InputSnapshotStore
+import com.forge.cache.TaskInputResolver
+import com.forge.execution.TaskGraphBuilder
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.core.subcommands
+import com.github.ajalt.clikt.parameters.arguments.argument
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+
+/**
+ * Cache inspection commands
+ */
+class CacheCommand : CliktCommand() {
+    override fun help(context: Context): String = "Inspect the task cache"
+    override fun run() = Unit
+
+    init {
+        subcommands(
+            WhyMissCommand()
+        )
+    }
+}
+
+/**
+ * Explain which input files changed since the last cached run of a task
+ */
+class WhyMissCommand : CliktCommand("why-miss") {
+    override fun help(context: Context): String = "List the inputs that changed since the last cached run of a task"
+    private val taskId by argument(name = "task", help = "Task to inspect (project:target)")
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        val parts = taskId.split(":", limit = 2)
+        if (parts.size != 2) {
+            echo("❌ Task must be specified as project:target", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+        val projectName = parts[0]
+        // Matrix tasks are addressed as project:target[NAME=value,...]
+        val targetName = parts[1].substringBefore("[")
+
+        val workspaceRoot = findWorkspaceRoot()
+        val projectGraph = discoverProjects(workspaceRoot)
+
+        val projectNode = projectGraph.nodes[projectName]
+        if (projectNode == null || !projectNode.data.hasTarget(targetName)) {
+            echo("❌ Task '$taskId' not found", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        val taskGraph = TaskGraphBuilder(projectGraph).buildTaskGraphForProjects(targetName, listOf(projectName))
+        val task = taskGraph.getTask(taskId)
+        if (task == null) {
+            echo("❌ Task '$taskId' not found", err = true)
+            val matrixTasks = taskGraph.getAllTasks().filter { it.id.startsWith("$taskId[") }
+            if (matrixTasks.isNotEmpty()) {
+                echo("Matrix entries:")
+                matrixTasks.forEach { echo("  • ${it.id}") }
+            }
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        val current = TaskInputResolver.forWorkspace(workspaceRoot, projectGraph).snapshot(task)
+        val previous = InputSnapshotStore(workspaceRoot).load(taskId)
+
+        if (previous == null) {
+            echo("ℹ️  No cached input snapshot for '$taskId' - it has not been cached yet")
+            return
+        }
+
+        val diff = InputSnapshotDiff.between(previous, current)
+
+        if (json) {
+            echo(ObjectMapper()
+                .writerWithDefaultPrettyPrinter()
+                .writeValueAsString(mapOf(
+                    "task" to taskId,
+                    "previousKey" to previous.key,
+                    "currentKey" to current.key,
+                    "configChanged" to diff.configChanged,
+                    "added" to diff.added,
+                    "removed" to diff.removed,
+                    "changed" to diff.changed
+                )))
+            return
+        }
+
+        echo("🔍 Cache analysis for '$taskId'")
+        echo("   Previous key: ${previous.key}")
+        echo("   Current key:  ${current.key}")
+        echo()
+
+        if (diff.isEmpty) {
+            echo("✅ Inputs are unchanged - the task should be a cache hit")
+            return
+        }
+
+        if (diff.configChanged) {
+            echo("⚙️  Target configuration changed")
+        }
+        diff.changed.forEach { echo("  ~ $it") }
+        diff.added.forEach { echo("  + $it") }
+        diff.removed.forEach { echo("  - $it") }
+    }
+}
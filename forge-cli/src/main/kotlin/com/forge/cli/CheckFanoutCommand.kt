@@ -0,0 +1,67 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.check.FanoutChecker
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.types.int
+
+/**
+ * Fail when a project has more direct dependents than allowed
+ */
+class CheckFanoutCommand : CliktCommand("check-fanout") {
+    override fun help(context: Context): String = "Check that no project has more dependents than the limit"
+    private val max by option("--max", help = "Maximum number of direct dependents (defaults to fanout.max in forge.json)").int()
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        val workspaceRoot = findWorkspaceRoot()
+        val (projectGraph, workspaceConfig) = discoverProjectsWithConfig(workspaceRoot)
+        val fanout = workspaceConfig?.fanout
+
+        val limit = max ?: fanout?.max
+        if (limit == null) {
+            echo("❌ No fan-out limit: pass --max or set fanout.max in forge.json", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        val checker = FanoutChecker(projectGraph, limit, fanout?.overrides ?: emptyMap())
+        val violations = checker.check()
+
+        if (json) {
+            echo(ObjectMapper()
+                .writerWithDefaultPrettyPrinter()
+                .writeValueAsString(mapOf(
+                    "max" to limit,
+                    "violations" to violations.map { violation ->
+                        mapOf(
+                            "project" to violation.project,
+                            "dependents" to violation.count,
+                            "limit" to violation.limit,
+                            "dependentProjects" to violation.dependents
+                        )
+                    }
+                )))
+        } else if (violations.isEmpty()) {
+            echo("✅ Every project is within its fan-out limit:")
+            projectGraph.getAllProjects().map { it.name }.sorted().forEach { name ->
+                echo("   • $name: ${projectGraph.getDependents(name).size} dependents (limit ${checker.limitFor(name)})")
+            }
+        } else {
+            echo("❌ Found ${violations.size} project(s) with too many dependents:")
+            echo("═".repeat(40))
+            violations.forEach { violation ->
+                echo("📦 ${violation.project}: ${violation.count} dependents (limit ${violation.limit})")
+                echo("   ${violation.dependents.joinToString(", ")}")
+            }
+            echo()
+            echo("💡 Consider splitting these projects, or raise their limit in fanout.overrides")
+        }
+
+        if (violations.isNotEmpty()) {
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+    }
+}
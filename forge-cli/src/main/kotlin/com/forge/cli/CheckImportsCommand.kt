@@ -0,0 +1,44 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.check.ImportPathChecker
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+
+/**
+ * Report Go files whose import paths disagree with their module path
+ */
+class CheckImportsCommand : CliktCommand("check-imports") {
+    override fun help(context: Context): String = "Check Go import paths against go.mod module paths"
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        val workspaceRoot = findWorkspaceRoot()
+        val projectGraph = discoverProjects(workspaceRoot)
+
+        val issues = ImportPathChecker(workspaceRoot, projectGraph).check()
+
+        if (json) {
+            echo(ObjectMapper()
+                .writerWithDefaultPrettyPrinter()
+                .writeValueAsString(mapOf("issues" to issues)))
+        } else if (issues.isEmpty()) {
+            echo("✅ All Go import paths match their module paths")
+        } else {
+            echo("❌ Found ${issues.size} import path issue(s):")
+            echo("═".repeat(40))
+            issues.groupBy { it.project }.toSortedMap().forEach { (project, projectIssues) ->
+                echo("📦 $project")
+                projectIssues.forEach { issue ->
+                    echo("   ${issue.file}:${issue.line}: ${issue.message}")
+                }
+            }
+        }
+
+        if (issues.isNotEmpty()) {
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+    }
+}
@@ -4,10 +4,23 @@ import com.github.ajalt.clikt.core.*
 import com.github.ajalt.clikt.parameters.arguments.argument
 import com.github.ajalt.clikt.parameters.options.*
 import com.github.ajalt.clikt.parameters.types.int
+import com.forge.core.ProjectConfiguration
+import com.forge.discovery.IncrementalProjectGraph
 import com.forge.discovery.ProjectDiscovery
+import com.forge.execution.ExecutionResults
 import com.forge.execution.ExecutorFactory
+import com.forge.execution.MissingOutputsException
+import com.forge.execution.OutputPackager
+import com.forge.execution.TargetDeprecations
 import com.forge.execution.TaskGraphBuilder
+import com.forge.execution.TestSummaries
+import com.forge.graph.ProjectGraphServer
+import com.forge.graph.TaskExecutionPlan
+import com.forge.graph.TaskStatus
 import com.forge.inference.InferenceEngine
+import com.forge.inference.InferenceError
+import com.forge.release.VersionResolver
+import com.forge.watch.WorkspaceWatcher
 import java.nio.file.Path
 import kotlin.io.path.absolute
 import kotlin.io.path.exists
@@ -23,10 +36,20 @@ class ForgeCli : CliktCommand() {
     This tool is inspired by Nx and provides project and task graph functionality
     for managing complex monorepo workspaces.
     """.trimIndent()
+    private val strict by option("--strict", help = "Fail when any project cannot be inferred").flag()
 
-    override fun run() = Unit
+    override fun run() {
+        currentContext.obj = WorkspaceOptions(strict = strict)
+    }
 }
 
+/**
+ * Options shared by every command that loads the workspace
+ */
+data class WorkspaceOptions(
+    val strict: Boolean = false
+)
+
 /**
  * Run a target for a specific project
  */
@@ -36,6 +59,7 @@ class RunCommand : CliktCommand() {
     private val target by argument(help = "Target name")
     private val dryRun by option("--dry-run", help = "Show what would be executed").flag()
     private val verbose by option("--verbose", help = "Show detailed execution plan").flag()
+    private val packagePath by option("--package", help = "Write the target's declared outputs to this .tar.gz after it succeeds")
 
     override fun run() {
         echo("🔧 Running target '$target' for project '$project'")
@@ -74,6 +98,7 @@ class RunCommand : CliktCommand() {
         }
 
         val executionPlan = taskGraph.getExecutionPlan()
+        echoDeprecationWarnings(executionPlan)
 
         if (verbose) {
             echo("📋 Execution Plan:")
@@ -90,22 +115,32 @@ class RunCommand : CliktCommand() {
                     echo("  • ${task.id}")
                 }
             }
+            packagePath?.let { echo("🔍 Would package the outputs of $project:$target into $it") }
         } else {
             echo("▶️  Executing ${executionPlan.totalTasks} task(s)...")
             
             // Execute tasks with unified executor (supports both local and remote execution)
             val executor = ExecutorFactory.createExecutor(workspaceRoot, projectGraph, workspaceConfig)
+            // Packaging reads the outputs from disk, so fetch them even when the task ran remotely or was cached
+            val downloadOutputs = if (packagePath == null) emptySet() else executionPlan.getAllTasks()
+                .filter { it.projectName == project && it.targetName == target }
+                .map { it.id }
+                .toSet()
             val results = try {
-                executor.execute(executionPlan, verbose)
+                executor.execute(executionPlan, verbose, downloadOutputs = downloadOutputs)
             } finally {
                 if (executor is AutoCloseable) {
                     executor.close()
                 }
             }
             
+            echoMatrixResults(results)
+            echoTestSummaries(executionPlan, results)
+            
             if (results.success) {
                 echo("✅ Task execution completed successfully!")
                 echo("   ${results.successCount} tasks completed in ${results.totalDuration}ms")
+                packagePath?.let { packageOutputs(workspaceRoot, projectNode.data, Path.of(it).absolute()) }
             } else {
                 echo("❌ Task execution failed!")
                 echo("   ${results.successCount} succeeded, ${results.failureCount} failed")
@@ -120,6 +155,27 @@ class RunCommand : CliktCommand() {
             }
         }
     }
+
+    private fun packageOutputs(workspaceRoot: Path, projectConfig: ProjectConfiguration, archive: Path) {
+        val targetConfig = projectConfig.targets.getValue(target)
+        if (targetConfig.outputs.isEmpty()) {
+            echo("❌ Target '$project:$target' declares no outputs to package", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        val packaged = try {
+            OutputPackager(workspaceRoot).packageOutputs(projectConfig, targetConfig, archive)
+        } catch (e: MissingOutputsException) {
+            echo("❌ Cannot package $project:$target, declared outputs were not produced:", err = true)
+            e.missing.forEach { echo("   • $it", err = true) }
+            throw com.github.ajalt.clikt.core.Abort()
+        } catch (e: IllegalArgumentException) {
+            echo("❌ Cannot package $project:$target: ${e.message}", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        echo("📦 Packaged ${packaged.size} output file(s) into $archive")
+    }
 }
 
 /**
@@ -134,6 +190,7 @@ class RunManyCommand : CliktCommand() {
     private val parallel by option("--parallel", help = "Max parallel tasks").int().default(3)
     private val dryRun by option("--dry-run", help = "Show what would be executed").flag()
     private val verbose by option("--verbose", help = "Show detailed execution plan").flag()
+    private val skipCached by option("--skip-cached", help = "Only execute cache misses; report cache hits as skipped").flag()
 
     override fun run() {
         if (targetName == null) {
@@ -194,6 +251,7 @@ class RunManyCommand : CliktCommand() {
         }
 
         val executionPlan = taskGraph.getExecutionPlan()
+        echoDeprecationWarnings(executionPlan)
 
         echo("📋 Execution Summary:")
         echo("  • Total tasks: ${executionPlan.totalTasks}")
@@ -222,16 +280,27 @@ class RunManyCommand : CliktCommand() {
             // Execute tasks with unified executor (supports both local and remote execution)
             val executor = ExecutorFactory.createExecutor(workspaceRoot, projectGraph)
             val results = try {
-                executor.execute(executionPlan, verbose)
+                executor.execute(executionPlan, verbose, skipCached)
             } finally {
                 if (executor is AutoCloseable) {
                     executor.close()
                 }
             }
             
+            val skippedTasks = results.results.values.filter { it.wasSkipped() }
+            if (skippedTasks.isNotEmpty()) {
+                echo("⏭️  Skipped ${skippedTasks.size} cached task(s):")
+                skippedTasks.sortedBy { it.task.id }.forEach { result ->
+                    echo("   • ${result.task.id}")
+                }
+            }
+            
+            echoMatrixResults(results)
+            echoTestSummaries(executionPlan, results)
+            
             if (results.success) {
                 echo("✅ Task execution completed successfully!")
-                echo("   ${results.successCount} tasks completed in ${results.totalDuration}ms")
+                echo("   ${results.successCount - skippedTasks.size} tasks completed in ${results.totalDuration}ms")
             } else {
                 echo("❌ Task execution failed!")
                 echo("   ${results.successCount} succeeded, ${results.failureCount} failed")
@@ -323,6 +392,7 @@ class ShowProjectCommand : CliktCommand("project") {
             }
             throw com.github.ajalt.clikt.core.Abort()
         }
+        val version = VersionResolver(workspaceRoot).resolve(project.data)
 
         if (json) {
             val projectData = mapOf(
@@ -330,6 +400,7 @@ class ShowProjectCommand : CliktCommand("project") {
                 "type" to project.data.projectType,
                 "root" to project.data.root,
                 "sourceRoot" to project.data.sourceRoot,
+                "version" to version.version,
                 "tags" to project.data.tags,
                 "targets" to project.data.targets
             )
@@ -344,6 +415,10 @@ class ShowProjectCommand : CliktCommand("project") {
             if (project.data.sourceRoot != null) {
                 echo("Source Root: ${project.data.sourceRoot}")
             }
+            if (version.version != null) {
+                echo("Version: ${version.version} (from ${version.source?.description})")
+            }
+            version.warnings.forEach { echo("⚠️  $it") }
             if (project.data.tags.isNotEmpty()) {
                 echo("Tags: ${project.data.tags.joinToString(", ")}")
             }
@@ -383,7 +458,15 @@ class ShowProjectCommand : CliktCommand("project") {
  */
 class GraphCommand : CliktCommand() {
     override fun help(context: Context): String = "Show project dependency graph"
+    private val serve by option("--serve", help = "Serve the graph and a browser viewer over HTTP").flag()
+    private val port by option("--port", help = "Port for --serve").int().default(ProjectGraphServer.DEFAULT_PORT)
+
     override fun run() {
+        if (serve) {
+            serveGraph()
+            return
+        }
+
         echo("🌐 Project dependency graph:")
         echo("═".repeat(40))
         echo()
@@ -415,9 +498,94 @@ class GraphCommand : CliktCommand() {
             }
         }
     }
+
+    private fun serveGraph() {
+        val workspaceRoot = findWorkspaceRoot()
+        val incremental = IncrementalProjectGraph(workspaceRoot)
+        var projectGraph = incremental.build()
+        reportInferenceErrors(incremental.inferenceErrors)
+        val server = ProjectGraphServer(projectGraph, port)
+        val boundPort = try {
+            server.start()
+        } catch (e: java.io.IOException) {
+            echo("❌ Could not start graph server on port $port: ${e.message}", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        val watcher = WorkspaceWatcher(workspaceRoot)
+        Runtime.getRuntime().addShutdownHook(Thread {
+            watcher.close()
+            server.close()
+        })
+
+        echo("🌐 Serving project graph at http://localhost:$boundPort")
+        echo("   Press Ctrl+C to stop")
+
+        // Re-infer only what the changed files affect instead of rediscovering the whole workspace
+        watcher.watch { changedFiles ->
+            try {
+                val updated = incremental.update(changedFiles)
+                if (updated != projectGraph) {
+                    projectGraph = updated
+                    server.update(projectGraph)
+                    echo("🔄 Graph refreshed after ${changedFiles.size} file change(s)")
+                }
+            } catch (e: Exception) {
+                echo("⚠️  Failed to refresh graph: ${e.message}", err = true)
+            }
+        }
+    }
+}
+
+/**
+ * Warn once about each deprecated target in the plan, however many projects run it
+ */
+internal fun CliktCommand.echoDeprecationWarnings(executionPlan: TaskExecutionPlan) {
+    val usages = TargetDeprecations.find(executionPlan)
+    if (usages.isEmpty()) return
+
+    usages.forEach { usage -> echo("⚠️  ${usage.warning()}", err = true) }
+    echo()
+}
+
+/**
+ * Print the status of each matrix entry, since matrix tasks share a project and target
+ */
+internal fun CliktCommand.echoMatrixResults(results: ExecutionResults) {
+    val matrixResults = results.results.values.filter { it.task.matrix.isNotEmpty() }.sortedBy { it.task.id }
+    if (matrixResults.isEmpty()) return
+
+    echo("🧮 Matrix results:")
+    matrixResults.forEach { result ->
+        val icon = when (result.status) {
+            TaskStatus.FAILED -> "❌"
+            TaskStatus.CACHED -> "⚡"
+            TaskStatus.SKIPPED -> "⏭️"
+            else -> "✅"
+        }
+        val label = result.task.matrix.entries.joinToString(", ") { (name, value) -> "$name=$value" }
+        echo("   $icon ${result.task.projectName}:${result.task.targetName} [$label]")
+    }
 }
 
-private fun findWorkspaceRoot(): Path {
+/**
+ * Print the merged package results of each aggregate test target, even when a package failed
+ */
+internal fun CliktCommand.echoTestSummaries(executionPlan: TaskExecutionPlan, results: ExecutionResults) {
+    val summaries = TestSummaries.find(executionPlan, results)
+    if (summaries.isEmpty()) return
+
+    echo("🧪 Test summaries:")
+    summaries.forEach { summary ->
+        val icon = if (summary.success) "✅" else "❌"
+        echo("   $icon ${summary.projectName}:${summary.targetName} ${summary.headline()}")
+        summary.results.filter { it.isFailure }.sortedBy { it.task.id }.forEach { result ->
+            echo("      ✗ ${result.task.targetName}: ${result.error}")
+        }
+    }
+}
+
+internal fun findWorkspaceRoot(): Path {
     var current = Path.of("").absolute()
     while (current.parent != null) {
         if (current.resolve("forge.json").exists() ||
@@ -430,16 +598,19 @@ private fun findWorkspaceRoot(): Path {
     return Path.of("").absolute()
 }
 
-private fun discoverProjects(workspaceRoot: Path): com.forge.core.ProjectGraph {
+internal fun CliktCommand.discoverProjects(workspaceRoot: Path): com.forge.core.ProjectGraph {
     val inferenceEngine = InferenceEngine()
     val discovery = ProjectDiscovery(workspaceRoot, enableInference = true, inferenceEngine = inferenceEngine)
-    return discovery.discoverProjects()
+    val projectGraph = discovery.discoverProjects()
+    reportInferenceErrors(discovery.inferenceErrors)
+    return projectGraph
 }
 
-private fun discoverProjectsWithConfig(workspaceRoot: Path): Pair<com.forge.core.ProjectGraph, com.forge.core.WorkspaceConfiguration?> {
+internal fun CliktCommand.discoverProjectsWithConfig(workspaceRoot: Path): Pair<com.forge.core.ProjectGraph, com.forge.core.WorkspaceConfiguration?> {
     val inferenceEngine = InferenceEngine()
     val discovery = ProjectDiscovery(workspaceRoot, enableInference = true, inferenceEngine = inferenceEngine)
     val projectGraph = discovery.discoverProjects()
+    reportInferenceErrors(discovery.inferenceErrors)
     
     // Convert old config format to new format
     val coreWorkspaceConfig = discovery.workspaceConfiguration?.let { oldConfig ->
@@ -449,6 +620,23 @@ private fun discoverProjectsWithConfig(workspaceRoot: Path): Pair<com.forge.core
     return projectGraph to coreWorkspaceConfig
 }
 
+/**
+ * Warn about projects skipped during inference, or abort in --strict mode
+ */
+internal fun CliktCommand.reportInferenceErrors(errors: List<InferenceError>) {
+    if (errors.isEmpty()) return
+
+    val strict = currentContext.findObject<WorkspaceOptions>()?.strict ?: false
+    echo("${if (strict) "❌" else "⚠️ "} Skipped ${errors.size} project(s) that could not be inferred:", err = true)
+    errors.forEach { error ->
+        echo("   • ${error.file ?: error.source}: ${error.message}", err = true)
+    }
+
+    if (strict) {
+        throw com.github.ajalt.clikt.core.Abort()
+    }
+}
+
 fun main(args: Array<String>) = ForgeCli()
     .subcommands(
         RunCommand(),
@@ -458,6 +646,15 @@ fun main(args: Array<String>) = ForgeCli()
             ShowProjectCommand()
         ),
         GraphCommand(),
-        PluginCommand()
+        PluginCommand(),
+        CacheCommand(),
+        WatchCommand(),
+        ReleasePlanCommand(),
+        AffectedCommand(),
+        WhatIfCommand(),
+        CheckImportsCommand(),
+        CheckFanoutCommand(),
+        MetricsCommand(),
+        LintConfigCommand()
     )
     .main(args)
\ No newline at end of file
@@ -0,0 +1,48 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.check.ConfigLinter
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+
+/**
+ * Report every problem in the resolved workspace configuration at once
+ */
+class LintConfigCommand : CliktCommand("lint-config") {
+    override fun help(context: Context): String = "Check project and target configuration for problems"
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        val workspaceRoot = findWorkspaceRoot()
+        val (projectGraph, workspaceConfig) = discoverProjectsWithConfig(workspaceRoot)
+
+        val issues = ConfigLinter(projectGraph, workspaceConfig?.namedInputs ?: emptyMap()).lint()
+
+        if (json) {
+            echo(ObjectMapper()
+                .writerWithDefaultPrettyPrinter()
+                .writeValueAsString(mapOf("issues" to issues)))
+        } else if (issues.isEmpty()) {
+            echo("✅ No configuration problems found in ${projectGraph.nodes.size} project(s)")
+        } else {
+            echo("❌ Found ${issues.size} configuration problem(s):")
+            echo("═".repeat(40))
+            issues.groupBy { it.project }.forEach { (project, projectIssues) ->
+                echo("📦 $project")
+                projectIssues.forEach { issue ->
+                    echo("   ${issue.target}: ${issue.message}")
+                }
+            }
+            echo()
+            echo("📊 " + issues.groupingBy { it.kind }.eachCount().entries
+                .sortedBy { it.key }
+                .joinToString(", ") { (kind, count) -> "$count ${kind.name.lowercase().replace('_', ' ')}" })
+        }
+
+        if (issues.isNotEmpty()) {
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+    }
+}
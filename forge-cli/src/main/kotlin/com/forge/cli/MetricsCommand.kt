@@ -0,0 +1,74 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.metrics.ClosureMetrics
+import com.forge.metrics.ClosureSort
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.core.subcommands
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.types.enum
+
+/**
+ * Architecture metrics commands
+ */
+class MetricsCommand : CliktCommand() {
+    override fun help(context: Context): String = "Report architecture metrics of the project graph"
+    override fun run() = Unit
+
+    init {
+        subcommands(
+            ClosureCommand()
+        )
+    }
+}
+
+/**
+ * Report each project's transitive dependency and dependent counts
+ */
+class ClosureCommand : CliktCommand("closure") {
+    override fun help(context: Context): String = "Report transitive dependency and dependent counts per project"
+    private val sort by option("--sort", help = "Sort by dependencies, dependents or name")
+        .enum<ClosureSort> { it.name.lowercase() }
+        .default(ClosureSort.DEPENDENCIES)
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        val workspaceRoot = findWorkspaceRoot()
+        val projectGraph = discoverProjects(workspaceRoot)
+
+        val closures = ClosureMetrics(projectGraph).compute(sort)
+
+        if (json) {
+            echo(ObjectMapper()
+                .writerWithDefaultPrettyPrinter()
+                .writeValueAsString(mapOf(
+                    "projects" to closures.map { closure ->
+                        mapOf(
+                            "project" to closure.project,
+                            "dependencies" to closure.dependencyCount,
+                            "dependents" to closure.dependentCount,
+                            "dependencyClosure" to closure.dependencies.sorted(),
+                            "dependentClosure" to closure.dependents.sorted()
+                        )
+                    }
+                )))
+            return
+        }
+
+        if (closures.isEmpty()) {
+            echo("📭 No projects found")
+            return
+        }
+
+        val width = maxOf(closures.maxOf { it.project.length }, "Project".length)
+        echo("📊 Transitive closure sizes (${closures.size} projects)")
+        echo("═".repeat(width + 28))
+        echo("${"Project".padEnd(width)}  Dependencies  Dependents")
+        closures.forEach { closure ->
+            echo("${closure.project.padEnd(width)}  ${closure.dependencyCount.toString().padStart(12)}  ${closure.dependentCount.toString().padStart(10)}")
+        }
+    }
+}
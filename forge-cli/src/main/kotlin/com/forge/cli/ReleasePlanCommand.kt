@@ -0,0 +1,56 @@
+package com.forge.cli
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.forge.release.ReleasePlanner
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+
+/**
+ * Show the ordered list of projects to build and publish for a release
+ */
+class ReleasePlanCommand : CliktCommand("release-plan") {
+    override fun help(context: Context): String = "Compute the build/publish order for a release"
+    private val targetName by option("--target", help = "Release target to run").default("publish")
+    private val tag by option("--tag", help = "Tag marking releasable projects").default(ReleasePlanner.DEFAULT_RELEASE_TAG)
+    private val json by option("--json", help = "Output in JSON format").flag()
+
+    override fun run() {
+        val workspaceRoot = findWorkspaceRoot()
+        val projectGraph = discoverProjects(workspaceRoot)
+
+        val plan = try {
+            ReleasePlanner(workspaceRoot, projectGraph).plan(targetName, tag)
+        } catch (e: IllegalStateException) {
+            echo("❌ ${e.message}", err = true)
+            throw com.github.ajalt.clikt.core.Abort()
+        }
+
+        if (json) {
+            echo(ObjectMapper()
+                .writerWithDefaultPrettyPrinter()
+                .writeValueAsString(mapOf(
+                    "target" to plan.target,
+                    "projects" to plan.entries
+                )))
+            return
+        }
+
+        if (plan.isEmpty()) {
+            echo("ℹ️  No projects tagged '$tag' have target '$targetName'")
+            return
+        }
+
+        echo("🚀 Release plan for target '$targetName':")
+        echo("═".repeat(40))
+        plan.entries.forEachIndexed { index, entry ->
+            echo("  ${index + 1}. ${entry.project} ${entry.version ?: "(unversioned)"}")
+            if (entry.dependsOn.isNotEmpty()) {
+                echo("     after: ${entry.dependsOn.joinToString(", ")}")
+            }
+            entry.warnings.forEach { echo("     ⚠️  $it") }
+        }
+    }
+}
@@ -0,0 +1,95 @@
+package com.forge.cli
+
+import com.forge.cache.TaskInputResolver
+import com.forge.config.WorkspaceConfigurationConverter
+import com.forge.core.ProjectGraph
+import com.forge.discovery.IncrementalProjectGraph
+import com.forge.execution.ExecutorFactory
+import com.forge.execution.TaskGraphBuilder
+import com.forge.watch.TargetWatchMatcher
+import com.forge.watch.WorkspaceWatcher
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.Context
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.split
+import java.nio.file.Path
+
+/**
+ * Watch the workspace and re-run only the targets whose inputs changed
+ */
+class WatchCommand : CliktCommand() {
+    override fun help(context: Context): String = "Re-run targets whose inputs change"
+    private val targets by option("--targets", help = "Targets to re-run on change").split(",").default(listOf("build", "test"))
+    private val projects by option("--projects", help = "Limit watching to these projects").split(",")
+    private val verbose by option("--verbose", help = "Show detailed execution output").flag()
+
+    override fun run() {
+        val workspaceRoot = findWorkspaceRoot()
+        val incremental = IncrementalProjectGraph(workspaceRoot)
+        var projectGraph = incremental.build()
+        reportInferenceErrors(incremental.inferenceErrors)
+        var matcher = matcherFor(workspaceRoot, projectGraph)
+
+        echo("👀 Watching $workspaceRoot for changes to targets: ${targets.joinToString(", ")}")
+        echo("   Press Ctrl+C to stop")
+        echo()
+
+        WorkspaceWatcher(workspaceRoot).use { watcher ->
+            watcher.watch { changedFiles ->
+                // Added projects and edited project.json or go.mod files change which targets match
+                val updated = try {
+                    incremental.update(changedFiles)
+                } catch (e: Exception) {
+                    echo("⚠️  Failed to refresh the project graph, keeping the previous one: ${e.message}", err = true)
+                    projectGraph
+                }
+                if (updated != projectGraph) {
+                    projectGraph = updated
+                    matcher = matcherFor(workspaceRoot, projectGraph)
+                    if (verbose) echo("🔄 Project graph refreshed: ${projectGraph.nodes.size} project(s)")
+                }
+                val workspaceConfig = WorkspaceConfigurationConverter.convert(incremental.workspaceConfiguration)
+
+                val matched = matcher.matchByTarget(changedFiles)
+                if (matched.isEmpty()) {
+                    if (verbose) echo("ℹ️  ${changedFiles.size} file(s) changed, no watched target inputs affected")
+                    return@watch
+                }
+
+                echo("📝 Changed: ${changedFiles.joinToString(", ")}")
+                matched.forEach { (targetName, projectNames) ->
+                    val taskGraph = TaskGraphBuilder(projectGraph).buildTaskGraphForProjects(targetName, projectNames)
+                    val executionPlan = taskGraph.getExecutionPlan()
+
+                    echo("▶️  Re-running '$targetName' for ${projectNames.joinToString(", ")}")
+                    val executor = ExecutorFactory.createExecutor(workspaceRoot, projectGraph, workspaceConfig)
+                    val results = try {
+                        executor.execute(executionPlan, verbose)
+                    } finally {
+                        if (executor is AutoCloseable) {
+                            executor.close()
+                        }
+                    }
+
+                    if (results.success) {
+                        echo("✅ ${results.successCount} task(s) completed in ${results.totalDuration}ms")
+                    } else {
+                        results.results.values.filter { !it.isSuccess }.forEach { result ->
+                            echo("   ✗ ${result.task.id}: ${result.error}")
+                        }
+                    }
+                }
+                echo()
+            }
+        }
+    }
+
+    private fun matcherFor(workspaceRoot: Path, projectGraph: ProjectGraph) = TargetWatchMatcher(
+        projectGraph = projectGraph,
+        inputResolver = TaskInputResolver.forWorkspace(workspaceRoot, projectGraph),
+        targetNames = targets.toSet(),
+        projectNames = projects?.toSet() ?: projectGraph.nodes.keys
+    )
+}
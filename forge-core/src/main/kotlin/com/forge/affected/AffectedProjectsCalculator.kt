@@ -0,0 +1,166 @@
+package com.forge.affected
+
+import com.forge.cache.TaskInputResolver
+import com.forge.core.ProjectGraph
+import org.slf4j.LoggerFactory
+import java.nio.file.Path
+import java.util.concurrent.TimeUnit
+
+/**
+ * Projects affected by a set of changed files
+ */
+data class AffectedProjects(
+    val changedFiles: List<String>,
+    val directlyAffected: Set<String>,
+    val affected: Set<String>
+) {
+    fun isEmpty(): Boolean = affected.isEmpty()
+}
+
+/**
+ * A target of an affected project, with the changed files that are among its inputs.
+ * A task whose inputs did not change keeps its cache key, so it would still be a cache hit.
+ */
+data class AffectedTask(
+    val taskId: String,
+    val project: String,
+    val changedInputs: List<String>,
+    val cacheable: Boolean = true
+) {
+    val wouldBeCached: Boolean get() = cacheable && changedInputs.isEmpty()
+}
+
+/**
+ * Computes which projects are affected by file changes, either from git or from a hypothetical set of files
+ */
+class AffectedProjectsCalculator(
+    private val workspaceRoot: Path,
+    private val projectGraph: ProjectGraph
+) {
+    private val logger = LoggerFactory.getLogger(AffectedProjectsCalculator::class.java)
+
+    companion object {
+        /**
+         * Workspace-level files whose change affects every project
+         */
+        val GLOBAL_FILES = setOf("forge.json", "nx.json")
+
+        /**
+         * Tag prefix naming the team that owns a project, e.g. team:payments
+         */
+        const val TEAM_TAG_PREFIX = "team:"
+    }
+
+    /**
+     * Compute the affected projects for the given workspace-relative (or absolute) file paths.
+     * A project is affected when it owns a changed file or depends on a project that does.
+     */
+    fun affectedByFiles(changedFiles: Collection<String>): AffectedProjects {
+        val normalizedFiles = changedFiles.map { normalize(it) }.distinct().sorted()
+
+        val directlyAffected = if (normalizedFiles.any { it in GLOBAL_FILES }) {
+            projectGraph.nodes.keys.toSet()
+        } else {
+            normalizedFiles.mapNotNull { ownerOf(it) }.toSet()
+        }
+
+        val affected = directlyAffected.toMutableSet()
+        directlyAffected.forEach { project ->
+            affected.addAll(projectGraph.getTransitiveDependents(project))
+        }
+
+        return AffectedProjects(
+            changedFiles = normalizedFiles,
+            directlyAffected = directlyAffected.toSortedSet(),
+            affected = affected.toSortedSet()
+        )
+    }
+
+    /**
+     * Compute the affected projects from git changes since the base ref, including uncommitted and untracked files
+     */
+    fun affectedSince(base: String): AffectedProjects = affectedByFiles(changedFilesSince(base))
+
+    /**
+     * List workspace-relative files changed since the base ref
+     */
+    fun changedFilesSince(base: String): List<String> {
+        val committed = git("diff", "--name-only", "--relative", "$base...HEAD")
+        val uncommitted = git("diff", "--name-only", "--relative", "HEAD")
+        val untracked = git("ls-files", "--others", "--exclude-standard")
+        return (committed + uncommitted + untracked).distinct().sorted()
+    }
+
+    /**
+     * Resolve the given target for every affected project that has it, noting which changed files it consumes.
+     * A project can be affected through a file its target does not read, e.g. a README next to the sources.
+     */
+    fun affectedTasks(
+        affected: AffectedProjects,
+        targetName: String,
+        inputResolver: TaskInputResolver = TaskInputResolver.forWorkspace(workspaceRoot, projectGraph)
+    ): List<AffectedTask> {
+        return affected.affected.mapNotNull { projectName ->
+            val target = projectGraph.getProject(projectName)?.data?.getTarget(targetName) ?: return@mapNotNull null
+            AffectedTask(
+                taskId = "$projectName:$targetName",
+                project = projectName,
+                changedInputs = affected.changedFiles.filter { inputResolver.matches(projectName, target, it) },
+                cacheable = target.isCacheable()
+            )
+        }
+    }
+
+    /**
+     * Narrow affected projects to those owned by a team, i.e. tagged `team:<name>`.
+     * A team's project stays affected when the change reaching it is in another team's code.
+     */
+    fun ownedBy(affected: AffectedProjects, team: String): AffectedProjects {
+        val owned = projectGraph.getProjectsByTag("$TEAM_TAG_PREFIX$team").map { it.name }.toSet()
+        return affected.copy(
+            directlyAffected = affected.directlyAffected.filter { it in owned }.toSortedSet(),
+            affected = affected.affected.filter { it in owned }.toSortedSet()
+        )
+    }
+
+    /**
+     * Find the project owning a file: the project with the longest root containing it.
+     * A workspace root project ("." or "") owns every file no deeper project claims.
+     */
+    fun ownerOf(file: String): String? {
+        val path = normalize(file)
+        return projectGraph.getAllProjects()
+            .filter { project ->
+                val root = normalize(project.data.root)
+                root.isEmpty() || path == root || path.startsWith("$root/")
+            }
+            .maxByOrNull { normalize(it.data.root).length }
+            ?.name
+    }
+
+    private fun normalize(file: String): String {
+        val path = Path.of(file)
+        val relative = if (path.isAbsolute) workspaceRoot.toAbsolutePath().relativize(path).toString() else file
+        val normalized = relative.replace('\\', '/').removePrefix("./").trimEnd('/')
+        return if (normalized == ".") "" else normalized
+    }
+
+    private fun git(vararg args: String): List<String> {
+        val process = ProcessBuilder(listOf("git") + args)
+            .directory(workspaceRoot.toFile())
+            .redirectErrorStream(true)
+            .start()
+        val output = process.inputStream.bufferedReader().readLines()
+
+        if (!process.waitFor(60, TimeUnit.SECONDS)) {
+            process.destroyForcibly()
+            throw IllegalStateException("git ${args.joinToString(" ")} timed out")
+        }
+        if (process.exitValue() != 0) {
+            throw IllegalStateException("git ${args.joinToString(" ")} failed: ${output.joinToString("\n")}")
+        }
+
+        logger.debug("git ${args.joinToString(" ")} reported ${output.size} file(s)")
+        return output.map { it.trim() }.filter { it.isNotEmpty() }
+    }
+}
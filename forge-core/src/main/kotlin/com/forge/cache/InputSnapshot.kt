@@ -0,0 +1,113 @@
+package com.forge.cache
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.fasterxml.jackson.module.kotlin.readValue
+import com.forge.graph.Task
+import org.slf4j.LoggerFactory
+import java.net.URLEncoder
+import java.nio.charset.StandardCharsets
+import java.nio.file.Files
+import java.nio.file.Path
+import java.security.MessageDigest
+import kotlin.io.path.exists
+
+/**
+ * Input file hashes captured for a task when its result was cached
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+data class InputSnapshot(
+    val taskId: String,
+    val key: String,
+    val configHash: String? = null,
+    val files: Map<String, String> = emptyMap(),
+    val createdAt: Long = System.currentTimeMillis()
+) {
+    companion object {
+        /**
+         * Create a snapshot whose key combines the task configuration hash with every input file hash
+         */
+        fun create(task: Task, files: Map<String, String>): InputSnapshot {
+            val hasher = MessageDigest.getInstance("SHA-256")
+            hasher.update((task.hash ?: "").toByteArray())
+            files.toSortedMap().forEach { (path, hash) ->
+                hasher.update(path.toByteArray())
+                hasher.update(hash.toByteArray())
+            }
+            val key = hasher.digest().joinToString("") { "%02x".format(it) }
+
+            return InputSnapshot(
+                taskId = task.id,
+                key = key,
+                configHash = task.hash,
+                files = files.toSortedMap()
+            )
+        }
+    }
+}
+
+/**
+ * Difference between a previously cached input snapshot and the current one
+ */
+data class InputSnapshotDiff(
+    val added: List<String>,
+    val removed: List<String>,
+    val changed: List<String>,
+    val configChanged: Boolean
+) {
+    val isEmpty: Boolean = added.isEmpty() && removed.isEmpty() && changed.isEmpty() && !configChanged
+
+    companion object {
+        fun between(previous: InputSnapshot, current: InputSnapshot): InputSnapshotDiff {
+            val added = (current.files.keys - previous.files.keys).sorted()
+            val removed = (previous.files.keys - current.files.keys).sorted()
+            val changed = current.files.keys.intersect(previous.files.keys)
+                .filter { current.files[it] != previous.files[it] }
+                .sorted()
+
+            return InputSnapshotDiff(
+                added = added,
+                removed = removed,
+                changed = changed,
+                configChanged = previous.configHash != current.configHash
+            )
+        }
+    }
+}
+
+/**
+ * Stores the last cached input snapshot of each task under .forge/cache/inputs
+ */
+class InputSnapshotStore(workspaceRoot: Path) {
+    private val logger = LoggerFactory.getLogger(InputSnapshotStore::class.java)
+    private val objectMapper = jacksonObjectMapper()
+    private val storeDir = workspaceRoot.resolve(".forge").resolve("cache").resolve("inputs")
+
+    /**
+     * Persist a snapshot, replacing any previous snapshot for the same task
+     */
+    fun save(snapshot: InputSnapshot) {
+        Files.createDirectories(storeDir)
+        objectMapper.writeValue(snapshotPath(snapshot.taskId).toFile(), snapshot)
+        logger.debug("Stored input snapshot for ${snapshot.taskId}: ${snapshot.key}")
+    }
+
+    /**
+     * Load the last stored snapshot for a task, if any
+     */
+    fun load(taskId: String): InputSnapshot? {
+        val path = snapshotPath(taskId)
+        if (!path.exists()) return null
+
+        return try {
+            objectMapper.readValue<InputSnapshot>(path.toFile())
+        } catch (e: Exception) {
+            logger.warn("Failed to read input snapshot for $taskId: ${e.message}")
+            null
+        }
+    }
+
+    private fun snapshotPath(taskId: String): Path {
+        return storeDir.resolve(URLEncoder.encode(taskId, StandardCharsets.UTF_8) + ".json")
+    }
+}
@@ -0,0 +1,215 @@
+package com.forge.cache
+
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.TargetConfiguration
+import com.forge.graph.Task
+import org.slf4j.LoggerFactory
+import java.nio.file.FileSystems
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.PathMatcher
+import java.security.MessageDigest
+import kotlin.io.path.exists
+import kotlin.io.path.isRegularFile
+
+/**
+ * Resolves the input patterns declared on a target into concrete workspace files
+ */
+class TaskInputResolver(
+    private val workspaceRoot: Path,
+    private val projectGraph: ProjectGraph,
+    private val namedInputs: Map<String, List<String>> = emptyMap()
+) {
+    private val logger = LoggerFactory.getLogger(TaskInputResolver::class.java)
+    private val matchers = mutableMapOf<String, PathMatcher>()
+
+    companion object {
+        private val IGNORED_DIRECTORIES = setOf(".git", ".forge", "node_modules")
+
+        /**
+         * Create a resolver using the named inputs declared in the workspace forge.json
+         */
+        fun forWorkspace(workspaceRoot: Path, projectGraph: ProjectGraph): TaskInputResolver {
+            val configPath = workspaceRoot.resolve("forge.json")
+            val namedInputs = if (configPath.exists()) {
+                try {
+                    val node = jacksonObjectMapper().readTree(configPath.toFile())
+                    node["namedInputs"]?.fields()?.asSequence()?.associate { (name, patterns) ->
+                        name to patterns.map { it.asText() }
+                    } ?: emptyMap()
+                } catch (e: Exception) {
+                    emptyMap()
+                }
+            } else {
+                emptyMap()
+            }
+            return TaskInputResolver(workspaceRoot, projectGraph, namedInputs)
+        }
+    }
+
+    /**
+     * Expand the target inputs into workspace-relative glob patterns.
+     * Exclusions keep their leading '!'.
+     */
+    fun resolvePatterns(projectName: String, target: TargetConfiguration): List<String> {
+        val project = projectGraph.getProject(projectName)?.data ?: return emptyList()
+        return target.getTaskInputs()
+            .flatMap { input -> expandInput(input, project, mutableSetOf()) }
+            .distinct()
+    }
+
+    /**
+     * Check whether a workspace-relative file path is an input of the target
+     */
+    fun matches(projectName: String, target: TargetConfiguration, relativePath: String): Boolean {
+        val patterns = resolvePatterns(projectName, target)
+        val path = normalize(relativePath)
+        val included = patterns.filterNot { it.startsWith("!") }.any { globMatches(it, path) }
+        return included && patterns.filter { it.startsWith("!") }.none { globMatches(it.substring(1), path) }
+    }
+
+    /**
+     * Resolve the target inputs to the sorted list of workspace-relative files they select
+     */
+    fun resolveFiles(projectName: String, target: TargetConfiguration): List<String> {
+        val patterns = resolvePatterns(projectName, target)
+        val includes = patterns.filterNot { it.startsWith("!") }
+        val excludes = patterns.filter { it.startsWith("!") }.map { it.substring(1) }
+
+        val files = sortedSetOf<String>()
+        includes.forEach { pattern ->
+            val baseDir = workspaceRoot.resolve(literalPrefix(pattern))
+            if (!baseDir.exists()) return@forEach
+
+            if (baseDir.isRegularFile()) {
+                files.add(normalize(workspaceRoot.relativize(baseDir).toString()))
+                return@forEach
+            }
+
+            Files.walk(baseDir).use { stream ->
+                stream.filter { it.isRegularFile() }
+                    .map { normalize(workspaceRoot.relativize(it).toString()) }
+                    .filter { path -> path.split("/").none { it in IGNORED_DIRECTORIES } }
+                    .filter { path -> globMatches(pattern, path) }
+                    .forEach { files.add(it) }
+            }
+        }
+
+        return files.filter { path -> excludes.none { globMatches(it, path) } }
+    }
+
+    /**
+     * Hash every input file of a task, keyed by workspace-relative path
+     */
+    fun hashInputs(task: Task): Map<String, String> {
+        return resolveFiles(task.projectName, task.target).associateWith { path ->
+            try {
+                sha256(Files.readAllBytes(workspaceRoot.resolve(path)))
+            } catch (e: Exception) {
+                logger.warn("Failed to hash input file $path: ${e.message}")
+                ""
+            }
+        }
+    }
+
+    /**
+     * Capture the current input snapshot of a task
+     */
+    fun snapshot(task: Task): InputSnapshot = InputSnapshot.create(task, hashInputs(task))
+
+    private fun expandInput(input: String, project: ProjectConfiguration, seen: MutableSet<String>): List<String> {
+        val negated = input.startsWith("!")
+        val raw = input.removePrefix("!")
+
+        val expanded = when {
+            raw.startsWith("^") -> {
+                val inputName = raw.substring(1)
+                projectGraph.getDependencies(project.name).flatMap { dependency ->
+                    val dependencyProject = projectGraph.getProject(dependency.target)?.data
+                    if (dependencyProject != null) {
+                        expandNamedInput(inputName, dependencyProject, mutableSetOf())
+                    } else {
+                        emptyList()
+                    }
+                }
+            }
+            isNamedInput(raw, project) -> expandNamedInput(raw, project, seen)
+            else -> listOf(resolveTokens(raw, project))
+        }
+
+        return if (negated) negate(expanded) else expanded
+    }
+
+    /**
+     * Exclude everything a negated input selects. Exclusions inside a negated named input
+     * are dropped, so files it excluded stay out as well.
+     */
+    private fun negate(patterns: List<String>): List<String> {
+        return patterns.filterNot { it.startsWith("!") }.map { "!$it" }
+    }
+
+    private fun expandNamedInput(name: String, project: ProjectConfiguration, seen: MutableSet<String>): List<String> {
+        if (!seen.add(name)) return emptyList()
+
+        val patterns = project.namedInputs[name]
+            ?: namedInputs[name]
+            ?: if (name == "default") listOf("{projectRoot}/**/*") else emptyList()
+
+        return patterns.flatMap { pattern ->
+            if (pattern.startsWith("^")) emptyList() else expandInput(pattern, project, seen)
+        }
+    }
+
+    private fun isNamedInput(input: String, project: ProjectConfiguration): Boolean {
+        if (input.contains("/") || input.contains("*") || input.contains("{")) return false
+        return input == "default" || project.namedInputs.containsKey(input) || namedInputs.containsKey(input)
+    }
+
+    private fun resolveTokens(pattern: String, project: ProjectConfiguration): String {
+        val resolved = pattern
+            .replace("{workspaceRoot}", "")
+            .replace("{projectRoot}", project.root)
+            .replace("{projectName}", project.name)
+        return normalize(resolved)
+    }
+
+    private fun normalize(path: String): String {
+        return path.replace('\\', '/')
+            .removePrefix("./")
+            .trimStart('/')
+    }
+
+    /**
+     * Leading directory segments of a pattern that contain no glob characters
+     */
+    private fun literalPrefix(pattern: String): String {
+        return pattern.split("/")
+            .takeWhile { segment -> segment.none { it in "*?[{" } }
+            .joinToString("/")
+    }
+
+    /**
+     * Glob matching where '**' may also match zero directories, as in Nx input patterns
+     */
+    private fun globMatches(pattern: String, path: String): Boolean {
+        val candidates = setOf(
+            pattern,
+            pattern.replace("/**/", "/"),
+            pattern.removePrefix("**/"),
+            pattern.removePrefix("**/").replace("/**/", "/")
+        )
+        val relativePath = Path.of(path)
+        return candidates.any { candidate ->
+            matchers.getOrPut(candidate) {
+                FileSystems.getDefault().getPathMatcher("glob:$candidate")
+            }.matches(relativePath)
+        }
+    }
+
+    private fun sha256(data: ByteArray): String {
+        val digest = MessageDigest.getInstance("SHA-256").digest(data)
+        return digest.joinToString("") { "%02x".format(it) }
+    }
+}
@@ -0,0 +1,253 @@
+package com.forge.check
+
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.TargetConfiguration
+
+/**
+ * Kinds of problems in the resolved workspace configuration
+ */
+enum class ConfigIssueKind(val description: String) {
+    EMPTY_COMMANDS("run-commands target has no commands to run"),
+    DEPENDS_ON_CYCLE("targets depend on each other in a cycle"),
+    UNRESOLVED_TOKEN("token is not one of {projectRoot}, {projectName} or {workspaceRoot}"),
+    OUTPUT_COLLISION("two targets write the same output path"),
+    DANGLING_REFERENCE("reference to a project, target or named input that does not exist")
+}
+
+/**
+ * A single problem found in a project's target configuration
+ */
+data class ConfigIssue(
+    val project: String,
+    val target: String,
+    val kind: ConfigIssueKind,
+    val message: String
+)
+
+/**
+ * Checks the resolved configuration of every project for problems that would
+ * only surface when a target runs, or silently do nothing.
+ *
+ * Targets are checked after workspace targetDefaults are applied, so problems
+ * inherited from forge.json are reported against each target that inherits
+ * them. Every issue is collected rather than stopping at the first one.
+ */
+class ConfigLinter(
+    private val projectGraph: ProjectGraph,
+    private val namedInputs: Map<String, List<String>> = emptyMap()
+) {
+    companion object {
+        private val RUN_COMMANDS_EXECUTORS = setOf(null, "forge:run-commands", "nx:run-commands", "@nx/run-commands")
+        private val KNOWN_TOKENS = setOf("projectRoot", "projectName", "workspaceRoot")
+
+        // {name} placeholders, skipping shell ${VAR} expansions and brace globs like *.{ts,tsx}
+        private val tokenRegex = Regex("""(?<!\$)\{([A-Za-z][\w.]*)}""")
+    }
+
+    private data class TargetRef(val project: String, val target: String) {
+        override fun toString() = "$project:$target"
+    }
+
+    private data class ResolvedOutput(val owner: TargetRef, val declared: String, val path: String, val isGlob: Boolean)
+
+    /**
+     * Lint every project, returning issues ordered by project, target and kind
+     */
+    fun lint(): List<ConfigIssue> {
+        val issues = mutableListOf<ConfigIssue>()
+
+        projectGraph.getAllProjects().forEach { node ->
+            node.data.targets.forEach { (targetName, target) ->
+                issues.addAll(checkCommands(node.data, targetName, target))
+                issues.addAll(checkTokens(node.data, targetName, target))
+                issues.addAll(checkReferences(node.data, targetName, target))
+            }
+        }
+        issues.addAll(checkOutputCollisions())
+        issues.addAll(checkCycles())
+
+        return issues.sortedWith(compareBy({ it.project }, { it.target }, { it.kind }, { it.message }))
+    }
+
+    private fun checkCommands(project: ProjectConfiguration, targetName: String, target: TargetConfiguration): List<ConfigIssue> {
+        if (target.executor !in RUN_COMMANDS_EXECUTORS) return emptyList()
+
+        val commands = when (val value = target.options["commands"]) {
+            is List<*> -> value.filterIsInstance<String>()
+            is String -> listOf(value)
+            else -> emptyList()
+        }
+        if (commands.any { it.isNotBlank() }) return emptyList()
+
+        return listOf(ConfigIssue(project.name, targetName, ConfigIssueKind.EMPTY_COMMANDS,
+            "target has no commands; set options.commands or a different executor"))
+    }
+
+    private fun checkTokens(project: ProjectConfiguration, targetName: String, target: TargetConfiguration): List<ConfigIssue> {
+        val fields = target.inputs.map { "inputs" to it } +
+            target.outputs.map { "outputs" to it } +
+            stringsIn(target.options).map { (path, value) -> "options.$path" to value }
+
+        return fields.flatMap { (field, value) ->
+            tokenRegex.findAll(value)
+                .map { it.groupValues[1] }
+                .filter { it !in KNOWN_TOKENS }
+                .distinct()
+                .map { token ->
+                    ConfigIssue(project.name, targetName, ConfigIssueKind.UNRESOLVED_TOKEN,
+                        "$field contains unknown token {$token} in \"$value\"")
+                }
+                .toList()
+        }
+    }
+
+    private fun checkReferences(project: ProjectConfiguration, targetName: String, target: TargetConfiguration): List<ConfigIssue> {
+        val issues = mutableListOf<ConfigIssue>()
+        fun report(message: String) = issues.add(ConfigIssue(project.name, targetName, ConfigIssueKind.DANGLING_REFERENCE, message))
+
+        target.dependsOn.forEach { dependency ->
+            when {
+                dependency.startsWith("^") -> Unit // Dependencies without the target are skipped by design
+                dependency.contains(":") -> {
+                    val (projectName, dependencyTarget) = dependency.split(":", limit = 2)
+                    val resolvedProject = if (projectName == "self") project.name else projectName
+                    val dependencyProject = projectGraph.getProject(resolvedProject)
+                    when {
+                        dependencyProject == null -> report("dependsOn \"$dependency\" refers to unknown project '$resolvedProject'")
+                        !dependencyProject.data.hasTarget(dependencyTarget) ->
+                            report("dependsOn \"$dependency\" refers to target '$dependencyTarget' which project '$resolvedProject' does not have")
+                    }
+                }
+                !project.hasTarget(dependency) -> report("dependsOn \"$dependency\" refers to a target this project does not have")
+            }
+        }
+
+        target.inputs.filter { it.startsWith("^") }.forEach { input ->
+            val name = input.removePrefix("^")
+            val defined = name == "default" || name in namedInputs ||
+                projectGraph.getAllProjects().any { name in it.data.namedInputs }
+            if (!defined) {
+                report("input \"$input\" refers to undefined named input '$name'")
+            }
+        }
+
+        return issues
+    }
+
+    private fun checkOutputCollisions(): List<ConfigIssue> {
+        val outputs = projectGraph.getAllProjects().flatMap { node ->
+            node.data.targets.flatMap { (targetName, target) ->
+                target.outputs.map { output ->
+                    val path = normalize(output
+                        .replace("{workspaceRoot}", "")
+                        .replace("{projectRoot}", node.data.root)
+                        .replace("{projectName}", node.name))
+                    ResolvedOutput(TargetRef(node.name, targetName), output, path, path.any { it in "*?[{" })
+                }
+            }
+        }.sortedWith(compareBy({ it.owner.project }, { it.owner.target }, { it.path }))
+
+        val issues = mutableListOf<ConfigIssue>()
+        outputs.forEachIndexed { index, output ->
+            outputs.drop(index + 1)
+                .filter { other -> other.owner != output.owner && collides(output, other) }
+                .forEach { other ->
+                    issues.add(ConfigIssue(other.owner.project, other.owner.target, ConfigIssueKind.OUTPUT_COLLISION,
+                        "output \"${other.declared}\" (${other.path}) overlaps output \"${output.declared}\" of ${output.owner}"))
+                }
+        }
+        return issues
+    }
+
+    /**
+     * Same path, or one literal output directory containing the other
+     */
+    private fun collides(first: ResolvedOutput, second: ResolvedOutput): Boolean {
+        if (first.path == second.path) return true
+        if (first.isGlob || second.isGlob) return false
+        return first.path.isEmpty() || second.path.isEmpty() ||
+            second.path.startsWith("${first.path}/") || first.path.startsWith("${second.path}/")
+    }
+
+    /**
+     * Find dependsOn cycles across all targets, reporting each cycle once
+     */
+    private fun checkCycles(): List<ConfigIssue> {
+        val edges = projectGraph.getAllProjects().flatMap { node ->
+            node.data.targets.map { (targetName, target) ->
+                TargetRef(node.name, targetName) to target.dependsOn.flatMap { resolveDependsOn(node.data, it) }.distinct()
+            }
+        }.toMap()
+
+        val cycles = mutableSetOf<List<TargetRef>>()
+        val visited = mutableSetOf<TargetRef>()
+        val stack = mutableListOf<TargetRef>()
+        val onStack = mutableSetOf<TargetRef>()
+
+        fun visit(ref: TargetRef) {
+            visited.add(ref)
+            stack.add(ref)
+            onStack.add(ref)
+
+            edges[ref].orEmpty().forEach { next ->
+                if (next in onStack) {
+                    val cycle = stack.subList(stack.indexOf(next), stack.size).toList()
+                    // Rotate so the same cycle found from another entry point is reported once
+                    val start = cycle.indexOf(cycle.minBy { it.toString() })
+                    cycles.add(cycle.drop(start) + cycle.take(start))
+                } else if (next !in visited) {
+                    visit(next)
+                }
+            }
+
+            stack.removeAt(stack.size - 1)
+            onStack.remove(ref)
+        }
+
+        edges.keys.sortedBy { it.toString() }.forEach { if (it !in visited) visit(it) }
+
+        return cycles.map { cycle ->
+            val first = cycle.first()
+            ConfigIssue(first.project, first.target, ConfigIssueKind.DEPENDS_ON_CYCLE,
+                "dependsOn cycle: ${(cycle + first).joinToString(" -> ")}")
+        }
+    }
+
+    /**
+     * Targets a dependsOn entry points at, resolved the way the task graph builder resolves them
+     */
+    private fun resolveDependsOn(project: ProjectConfiguration, dependency: String): List<TargetRef> {
+        return when {
+            dependency.startsWith("^") -> {
+                val targetName = dependency.substring(1)
+                projectGraph.getDependencies(project.name)
+                    .filter { projectGraph.getProject(it.target)?.data?.hasTarget(targetName) == true }
+                    .map { TargetRef(it.target, targetName) }
+            }
+            dependency.contains(":") -> {
+                val (projectName, targetName) = dependency.split(":", limit = 2)
+                val resolvedProject = if (projectName == "self") project.name else projectName
+                if (projectGraph.getProject(resolvedProject)?.data?.hasTarget(targetName) == true) {
+                    listOf(TargetRef(resolvedProject, targetName))
+                } else {
+                    emptyList()
+                }
+            }
+            project.hasTarget(dependency) -> listOf(TargetRef(project.name, dependency))
+            else -> emptyList()
+        }
+    }
+
+    /**
+     * Every string nested in target options, keyed by its dotted path
+     */
+    private fun stringsIn(value: Any?, path: String = ""): List<Pair<String, String>> = when (value) {
+        is String -> listOf(path to value)
+        is Map<*, *> -> value.entries.flatMap { (key, nested) -> stringsIn(nested, if (path.isEmpty()) "$key" else "$path.$key") }
+        is List<*> -> value.flatMapIndexed { index, nested -> stringsIn(nested, "$path[$index]") }
+        else -> emptyList()
+    }
+
+    private fun normalize(path: String): String = path.replace('\\', '/').removePrefix("./").trimStart('/').trimEnd('/')
+}
@@ -0,0 +1,43 @@
+package com.forge.check
+
+import com.forge.core.ProjectGraph
+
+/**
+ * A project with more direct dependents than its limit allows
+ */
+data class FanoutViolation(
+    val project: String,
+    val dependents: List<String>,
+    val limit: Int
+) {
+    val count: Int get() = dependents.size
+}
+
+/**
+ * Finds projects depended on by too many others.
+ *
+ * A library that most of the workspace depends on turns every change into a
+ * workspace-wide rebuild, which usually means it should be split. Each
+ * project's direct dependents are counted against the workspace limit or its
+ * own override.
+ */
+class FanoutChecker(
+    private val projectGraph: ProjectGraph,
+    private val max: Int,
+    private val overrides: Map<String, Int> = emptyMap()
+) {
+    /**
+     * Check every project, returning violations with the most dependents first
+     */
+    fun check(): List<FanoutViolation> {
+        return projectGraph.getAllProjects()
+            .mapNotNull { project ->
+                val limit = limitFor(project.name)
+                val dependents = projectGraph.getDependents(project.name).sorted()
+                if (dependents.size > limit) FanoutViolation(project.name, dependents, limit) else null
+            }
+            .sortedWith(compareByDescending<FanoutViolation> { it.count }.thenBy { it.project })
+    }
+
+    fun limitFor(project: String): Int = overrides[project] ?: max
+}
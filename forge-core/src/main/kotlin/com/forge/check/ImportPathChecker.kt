@@ -0,0 +1,184 @@
+package com.forge.check
+
+import com.forge.core.ProjectGraph
+import com.forge.inference.GoSource
+import java.nio.file.FileVisitResult
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.SimpleFileVisitor
+import java.nio.file.attribute.BasicFileAttributes
+import kotlin.io.path.exists
+import kotlin.io.path.name
+import kotlin.io.path.readText
+
+/**
+ * Kinds of Go import path inconsistencies
+ */
+enum class ImportIssueKind(val description: String) {
+    CANONICAL_IMPORT_MISMATCH("package import comment does not match module path and directory"),
+    PACKAGE_NAME_MISMATCH("package name differs from other files in the directory"),
+    MISSING_PACKAGE("import refers to a package missing from this module"),
+    FOREIGN_INTERNAL_IMPORT("import of another module's internal package"),
+    UNREQUIRED_MODULE("import of a workspace module not required in go.mod"),
+    UNKNOWN_MODULE("import does not match the module path or any required module")
+}
+
+/**
+ * A single import path inconsistency in a Go file
+ */
+data class ImportIssue(
+    val project: String,
+    val file: String,
+    val line: Int,
+    val kind: ImportIssueKind,
+    val message: String
+)
+
+/**
+ * Verifies that Go files agree with their project's go.mod module path.
+ *
+ * Code copied between modules often keeps the old module's import paths or
+ * package import comments, which makes builds fail in confusing ways. Each
+ * file is checked against the module path and its directory; imports are
+ * checked against the module itself, the other workspace modules and the
+ * modules required in go.mod.
+ */
+class ImportPathChecker(
+    private val workspaceRoot: Path,
+    private val projectGraph: ProjectGraph
+) {
+    companion object {
+        private val SKIPPED_DIRECTORIES = setOf("vendor", "testdata", "node_modules")
+        private val majorVersionSuffix = Regex("""/v\d+$""")
+    }
+
+    private data class GoModule(val project: String, val root: Path, val modulePath: String, val requires: Set<String>)
+
+    private data class GoFile(val path: Path, val dir: String, val content: String)
+
+    /**
+     * Check every Go project of the workspace, returning issues ordered by file and line
+     */
+    fun check(): List<ImportIssue> {
+        val modules = projectGraph.getAllProjects().mapNotNull { project ->
+            val root = workspaceRoot.resolve(project.data.root)
+            val goMod = root.resolve("go.mod")
+            if (!goMod.exists()) return@mapNotNull null
+            val content = goMod.readText()
+            val modulePath = GoSource.modulePath(content) ?: return@mapNotNull null
+            GoModule(project.name, root, modulePath, GoSource.requiredModules(content))
+        }
+
+        return modules
+            .flatMap { module -> checkModule(module, modules) }
+            .sortedWith(compareBy({ it.file }, { it.line }))
+    }
+
+    private fun checkModule(module: GoModule, allModules: List<GoModule>): List<ImportIssue> {
+        val files = collectGoFiles(module.root)
+        val packageDirs = files.filterNot { it.path.name.endsWith("_test.go") }.map { it.dir }.toSet()
+        val otherModules = allModules.filter { it.modulePath != module.modulePath }
+        val issues = mutableListOf<ImportIssue>()
+
+        fun report(file: GoFile, line: Int, kind: ImportIssueKind, message: String) {
+            val relativeFile = workspaceRoot.relativize(file.path).toString().replace('\\', '/')
+            issues.add(ImportIssue(module.project, relativeFile, line, kind, message))
+        }
+
+        files.groupBy { it.dir }.forEach { (dir, dirFiles) ->
+            val expectedImportPath = if (dir == ".") module.modulePath else "${module.modulePath}/$dir"
+            val clauses = dirFiles.associateWith { GoSource.packageClause(it.content) }
+
+            val packageNames = clauses.values.filterNotNull()
+                .map { it.name.removeSuffix("_test") }
+                .groupingBy { it }.eachCount()
+            val expectedName = packageNames.entries
+                .sortedWith(compareByDescending<Map.Entry<String, Int>> { it.value }.thenBy { it.key })
+                .firstOrNull()?.key
+
+            clauses.forEach { (file, clause) ->
+                if (clause == null) return@forEach
+                if (clause.canonicalImport != null && clause.canonicalImport != expectedImportPath) {
+                    report(file, clause.line, ImportIssueKind.CANONICAL_IMPORT_MISMATCH,
+                        "package import comment \"${clause.canonicalImport}\" should be \"$expectedImportPath\"")
+                }
+                if (expectedName != null && clause.name.removeSuffix("_test") != expectedName) {
+                    report(file, clause.line, ImportIssueKind.PACKAGE_NAME_MISMATCH,
+                        "package ${clause.name} differs from package $expectedName used by other files in $expectedImportPath")
+                }
+            }
+        }
+
+        files.forEach { file ->
+            GoSource.imports(file.content).forEach { goImport ->
+                val path = goImport.path
+                val owner = otherModules
+                    .filter { path == it.modulePath || path.startsWith("${it.modulePath}/") }
+                    .maxByOrNull { it.modulePath.length }
+
+                when {
+                    path == module.modulePath || path.startsWith("${module.modulePath}/") -> {
+                        val dir = if (path == module.modulePath) "." else path.removePrefix("${module.modulePath}/")
+                        if (dir !in packageDirs) {
+                            report(file, goImport.line, ImportIssueKind.MISSING_PACKAGE,
+                                "\"$path\" has no Go files in module ${module.modulePath}")
+                        }
+                    }
+                    owner != null -> {
+                        val subPath = path.removePrefix(owner.modulePath).trim('/')
+                        if (subPath.split("/").contains("internal")) {
+                            report(file, goImport.line, ImportIssueKind.FOREIGN_INTERNAL_IMPORT,
+                                "\"$path\" is internal to module ${owner.modulePath} (project ${owner.project})")
+                        }
+                        if (owner.modulePath !in module.requires) {
+                            report(file, goImport.line, ImportIssueKind.UNREQUIRED_MODULE,
+                                "\"$path\" belongs to module ${owner.modulePath} (project ${owner.project}) which go.mod does not require")
+                        }
+                    }
+                    isSiblingPath(path, module) && module.requires.none { path == it || path.startsWith("$it/") } -> {
+                        report(file, goImport.line, ImportIssueKind.UNKNOWN_MODULE,
+                            "\"$path\" does not match module ${module.modulePath} or any required module")
+                    }
+                }
+            }
+        }
+
+        return issues
+    }
+
+    /**
+     * Whether an import shares the module's organisation prefix, e.g. github.com/example/ for github.com/example/payments
+     */
+    private fun isSiblingPath(path: String, module: GoModule): Boolean {
+        val basePath = module.modulePath.replace(majorVersionSuffix, "")
+        if (!basePath.contains("/")) return false
+        return path.startsWith(basePath.substringBeforeLast("/") + "/")
+    }
+
+    private fun collectGoFiles(moduleRoot: Path): List<GoFile> {
+        val files = mutableListOf<GoFile>()
+
+        Files.walkFileTree(moduleRoot, object : SimpleFileVisitor<Path>() {
+            override fun preVisitDirectory(dir: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (dir == moduleRoot) return FileVisitResult.CONTINUE
+                val name = dir.name
+                return if (name in SKIPPED_DIRECTORIES || name.startsWith(".") || name.startsWith("_") ||
+                    dir.resolve("go.mod").exists()) {
+                    FileVisitResult.SKIP_SUBTREE
+                } else {
+                    FileVisitResult.CONTINUE
+                }
+            }
+
+            override fun visitFile(file: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (attrs.isRegularFile && file.name.endsWith(".go")) {
+                    val dir = moduleRoot.relativize(file.parent).toString().replace('\\', '/').ifEmpty { "." }
+                    files.add(GoFile(file, dir, file.readText()))
+                }
+                return FileVisitResult.CONTINUE
+            }
+        })
+
+        return files.sortedBy { it.path.toString() }
+    }
+}
@@ -20,7 +20,8 @@ data class WorkspaceConfiguration(
     val workspaceLayout: WorkspaceLayout = WorkspaceLayout(),
     val cli: CliConfiguration = CliConfiguration(),
     @JsonProperty("affected")
-    val affected: AffectedConfiguration = AffectedConfiguration()
+    val affected: AffectedConfiguration = AffectedConfiguration(),
+    val fanout: FanoutConfiguration = FanoutConfiguration()
 ) {
     fun getTargetDefaults(targetName: String): TargetConfiguration? = 
         targetDefaults[targetName]
@@ -44,7 +45,8 @@ data class WorkspaceConfiguration(
         "defaultProject" to (defaultProject ?: ""),
         "workspaceLayout" to workspaceLayout,
         "cli" to cli,
-        "affected" to affected
+        "affected" to affected,
+        "fanout" to fanout
     )
 }
 
@@ -68,7 +70,17 @@ data class CliConfiguration(
 @JsonIgnoreProperties(ignoreUnknown = true)
 data class AffectedConfiguration(
     @JsonProperty("defaultBase")
-    val defaultBase: String = "main"
+    val defaultBase: String = "main",
+    val team: String? = null
+)
+
+/**
+ * Dependent limits from the `fanout` section of forge.json
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+data class FanoutConfiguration(
+    val max: Int? = null,
+    val overrides: Map<String, Int> = emptyMap()
 )
 
 @JsonIgnoreProperties(ignoreUnknown = true)
@@ -39,9 +39,13 @@ object WorkspaceConfigurationConverter {
             namedInputs = oldConfig.namedInputs,
             generators = oldConfig.generators,
             tasksRunnerOptions = oldConfig.tasksRunnerOptions,
-            affected = com.forge.core.AffectedConfiguration(defaultBase = "main"),
+            affected = com.forge.core.AffectedConfiguration(
+                defaultBase = oldConfig.affected.defaultBase,
+                team = oldConfig.affected.team
+            ),
             cli = com.forge.core.CliConfiguration(packageManager = "npm", defaultCollection = "@forge/workspace"),
-            remoteExecution = remoteExecutionConfig
+            remoteExecution = remoteExecutionConfig,
+            fanout = oldConfig.fanout
         )
     }
     
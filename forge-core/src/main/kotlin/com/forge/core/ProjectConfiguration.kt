@@ -16,7 +16,8 @@ data class ProjectConfiguration(
     val targets: Map<String, TargetConfiguration> = emptyMap(),
     val generators: Map<String, Any> = emptyMap(),
     @JsonProperty("namedInputs") 
-    val namedInputs: Map<String, List<String>> = emptyMap()
+    val namedInputs: Map<String, List<String>> = emptyMap(),
+    val version: String? = null
 ) {
     fun getTarget(name: String): TargetConfiguration? = targets[name]
     
@@ -41,10 +42,17 @@ data class TargetConfiguration(
     val inputs: List<String> = emptyList(),
     val outputs: List<String> = emptyList(),
     val cache: Boolean = true,
+    @JsonProperty("cacheOutputs")
+    val cacheOutputs: Boolean? = null,
+    @JsonProperty("cacheLogs")
+    val cacheLogs: Boolean? = null,
     @JsonProperty("parallelism") 
     val parallelism: Boolean = true,
     @JsonProperty("remoteExecution")
-    val remoteExecution: RemoteExecutionTargetConfig? = null
+    val remoteExecution: RemoteExecutionTargetConfig? = null,
+    val retry: RetryPolicy? = null,
+    val matrix: Map<String, List<String>> = emptyMap(),
+    val deprecated: TargetDeprecation? = null
 ) {
     fun getDependencies(): List<String> = dependsOn
     
@@ -54,8 +62,21 @@ data class TargetConfiguration(
     
     fun isCacheable(): Boolean = cache
     
+    /**
+     * Whether a cache hit restores the target's output files. Off unless cacheOutputs is set, so a
+     * hit only reports the cached result.
+     */
+    fun shouldCacheOutputs(): Boolean = cache && cacheOutputs == true
+    
+    /**
+     * Whether a cache hit replays the stdout/stderr of the cached run. Off unless cacheLogs is set.
+     */
+    fun shouldCacheLogs(): Boolean = cache && cacheLogs == true
+    
     fun canRunInParallel(): Boolean = parallelism
     
+    fun isDeprecated(): Boolean = deprecated != null
+    
     fun getConfiguration(name: String): Map<String, Any> = 
         configurations[name] ?: emptyMap()
     
@@ -64,6 +85,23 @@ data class TargetConfiguration(
     fun isRemoteExecutionEnabled(): Boolean = remoteExecution != null
     
     fun getRemoteExecutionConfig(): RemoteExecutionTargetConfig? = remoteExecution
+    
+    fun hasMatrix(): Boolean = matrix.isNotEmpty()
+    
+    /**
+     * Every combination of matrix environment values, in declaration order
+     */
+    fun getMatrixCombinations(): List<Map<String, String>> =
+        matrix.entries.fold(listOf(emptyMap())) { combinations, (name, values) ->
+            combinations.flatMap { combination -> values.map { value -> combination + (name to value) } }
+        }
+    
+    companion object {
+        /**
+         * Executor of an aggregate target whose result is the merged results of the test targets it depends on
+         */
+        const val TEST_SUMMARY_EXECUTOR = "forge:test-summary"
+    }
 }
 
 /**
@@ -81,4 +119,40 @@ data class RemoteExecutionTargetConfig(
     val instanceName: String? = null,
     @JsonProperty("enabled")
     val enabled: Boolean = true
-)
\ No newline at end of file
+)
+
+/**
+ * When to re-run a failed target. With no exit codes or output patterns every failure is retried;
+ * otherwise only failures matching one of them are, and all other failures fail immediately.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+data class RetryPolicy(
+    @JsonProperty("maxRetries")
+    val maxRetries: Int = 0,
+    @JsonProperty("exitCodes")
+    val exitCodes: List<Int> = emptyList(),
+    @JsonProperty("outputPatterns")
+    val outputPatterns: List<String> = emptyList(),
+    @JsonProperty("delayMillis")
+    val delayMillis: Long = 0
+) {
+    private val outputRegexes by lazy { outputPatterns.map { Regex(it) } }
+    
+    /**
+     * Whether a failed attempt with this exit code and stdout/stderr output should be retried
+     */
+    fun isRetryable(exitCode: Int, output: String): Boolean {
+        if (exitCode == 0 || maxRetries <= 0) return false
+        if (exitCodes.isEmpty() && outputPatterns.isEmpty()) return true
+        return exitCode in exitCodes || outputRegexes.any { it.containsMatchIn(output) }
+    }
+}
+
+/**
+ * Marks a target as deprecated, optionally naming the target to run instead
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+data class TargetDeprecation(
+    val replacement: String? = null,
+    val message: String? = null
+)
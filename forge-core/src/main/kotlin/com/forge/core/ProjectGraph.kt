@@ -13,6 +13,12 @@ data class ProjectGraph(
     fun getDependencies(projectName: String): List<ProjectGraphDependency> = 
         dependencies[projectName] ?: emptyList()
     
+    /**
+     * Projects that depend directly on the given project
+     */
+    fun getDependents(projectName: String): Set<String> =
+        dependencies.values.flatten().filter { it.target == projectName }.map { it.source }.toSet()
+    
     fun getAllProjects(): List<ProjectGraphNode> = nodes.values.toList()
     
     fun getProjectsByTag(tag: String): List<ProjectGraphNode> = 
@@ -4,6 +4,7 @@ import com.fasterxml.jackson.annotation.JsonIgnoreProperties
 import com.fasterxml.jackson.databind.JsonNode
 import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
 import com.fasterxml.jackson.module.kotlin.readValue
+import com.forge.config.FanoutConfiguration
 import com.forge.plugin.PluginSpec
 import com.forge.plugin.PluginSource
 import java.nio.file.Path
@@ -21,7 +22,8 @@ data class WorkspaceConfiguration(
     val tasksRunnerOptions: Map<String, Any> = emptyMap(),
     val affected: AffectedConfiguration = AffectedConfiguration(),
     val cli: CliConfiguration = CliConfiguration(),
-    val remoteExecution: RemoteExecutionWorkspaceConfig? = null
+    val remoteExecution: RemoteExecutionWorkspaceConfig? = null,
+    val fanout: FanoutConfiguration = FanoutConfiguration()
 ) {
     companion object {
         private val objectMapper = jacksonObjectMapper()
@@ -97,6 +99,12 @@ data class WorkspaceConfiguration(
                     emptyMap()
                 }
                 
+                val fanout = if (jsonNode.has("fanout")) {
+                    objectMapper.convertValue(jsonNode["fanout"], FanoutConfiguration::class.java)
+                } else {
+                    FanoutConfiguration()
+                }
+                
                 val remoteExecution = if (jsonNode.has("remoteExecution")) {
                     objectMapper.convertValue(jsonNode["remoteExecution"], RemoteExecutionWorkspaceConfig::class.java)
                 } else {
@@ -109,7 +117,8 @@ data class WorkspaceConfiguration(
                     namedInputs = namedInputs,
                     generators = generators,
                     tasksRunnerOptions = tasksRunnerOptions,
-                    remoteExecution = remoteExecution
+                    remoteExecution = remoteExecution,
+                    fanout = fanout
                 )
             } else {
                 // Standard format
@@ -205,7 +214,8 @@ data class WorkspaceConfiguration(
  */
 @JsonIgnoreProperties(ignoreUnknown = true)
 data class AffectedConfiguration(
-    val defaultBase: String = "main"
+    val defaultBase: String = "main",
+    val team: String? = null
 )
 
 /**
@@ -0,0 +1,290 @@
+package com.forge.discovery
+
+import com.forge.config.WorkspaceConfiguration
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphNode
+import com.forge.inference.CreateDependenciesContext
+import com.forge.inference.CreateNodesContext
+import com.forge.inference.CreateNodesResult
+import com.forge.inference.InferenceEngine
+import com.forge.inference.InferenceError
+import com.forge.inference.RawProjectGraphDependency
+import com.forge.plugin.ForgePlugin
+import org.slf4j.LoggerFactory
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.isRegularFile
+
+/**
+ * Keeps a project graph up to date as files change, for long-lived processes such as the daemon.
+ *
+ * Each configuration file's inferred nodes and each project's outgoing edges are cached. A change
+ * re-infers the configuration files that changed and those of the project owning a changed file,
+ * since plugins also read sources such as //forge: annotations. Edges are re-resolved for the projects
+ * owning the changed files, plus the dependents of any project whose configuration changed. Adding,
+ * removing or renaming a project re-resolves every edge, since other projects may now point at it.
+ * Versions are not part of the graph (they resolve on demand), so VERSION files and git tags need
+ * no refresh. The result matches what [ProjectDiscovery.discoverProjects] computes from scratch;
+ * legacy discovery plugins are not supported.
+ */
+class IncrementalProjectGraph(
+    private val workspaceRoot: Path,
+    private val inferenceEngine: InferenceEngine = InferenceEngine()
+) {
+    private val logger = LoggerFactory.getLogger(IncrementalProjectGraph::class.java)
+    private val discovery = ProjectDiscovery(workspaceRoot, enableInference = true, inferenceEngine = inferenceEngine)
+
+    companion object {
+        private val WORKSPACE_FILES = setOf("forge.json", "nx.json")
+        private const val PROJECT_FILE = "project.json"
+        private val IGNORED_DIRECTORIES = setOf(".git", ".forge", "node_modules")
+    }
+
+    private lateinit var workspaceConfig: WorkspaceConfiguration
+    private lateinit var plugins: List<ForgePlugin>
+    private val explicitProjects = sortedMapOf<String, ProjectConfiguration>()
+    private val explicitErrors = mutableMapOf<String, InferenceError>()
+    private val nodesByPlugin = mutableMapOf<String, MutableMap<String, CreateNodesResult>>()
+    private val pluginErrors = mutableMapOf<String, List<InferenceError>>()
+    private val dependenciesBySource = mutableMapOf<String, List<RawProjectGraphDependency>>()
+    private var rawProjects: Map<String, ProjectConfiguration> = emptyMap()
+    private var configuredProjects: Map<String, ProjectConfiguration> = emptyMap()
+
+    /**
+     * The current graph; call [build] first
+     */
+    lateinit var graph: ProjectGraph
+        private set
+
+    /**
+     * The workspace configuration the current graph was built with
+     */
+    val workspaceConfiguration: WorkspaceConfiguration
+        get() = workspaceConfig
+
+    /**
+     * Configuration files skipped in the current graph because they could not be read
+     */
+    val inferenceErrors: List<InferenceError>
+        get() = explicitErrors.values.sortedBy { it.file } + pluginErrors.values.flatten()
+
+    /**
+     * Compute the whole graph, filling the caches used by [update]
+     */
+    fun build(): ProjectGraph {
+        workspaceConfig = discovery.loadWorkspaceConfiguration()
+        plugins = inferenceEngine.loadPlugins(workspaceRoot)
+
+        explicitProjects.clear()
+        explicitErrors.clear()
+        findProjectFiles().forEach { readExplicitProject(it) }
+
+        nodesByPlugin.clear()
+        pluginErrors.clear()
+        val context = nodesContext()
+        plugins.forEach { plugin ->
+            val results = linkedMapOf<String, CreateNodesResult>()
+            // Infer one file at a time so a change can replace exactly that file's nodes
+            inferenceEngine.findConfigFiles(workspaceRoot, plugin).sorted().forEach { file ->
+                results[relativize(Path.of(file))] = inferenceEngine.createNodes(plugin, listOf(file), context)
+            }
+            nodesByPlugin[plugin.metadata.id] = results
+        }
+
+        rawProjects = mergeProjects()
+        configuredProjects = discovery.applyWorkspaceDefaults(rawProjects, workspaceConfig)
+        dependenciesBySource.clear()
+        resolveDependencies(configuredProjects.keys)
+
+        graph = assemble()
+        logger.info("Built project graph with ${graph.nodes.size} projects")
+        return graph
+    }
+
+    /**
+     * Apply a batch of changed workspace-relative (or absolute) files and return the updated graph
+     */
+    fun update(changedFiles: Collection<String>): ProjectGraph {
+        val files = changedFiles.map { normalize(it) }.filterNot { isIgnored(it) }.distinct()
+        if (files.isEmpty()) return graph
+
+        if (files.any { it in WORKSPACE_FILES }) {
+            logger.info("Workspace configuration changed, rebuilding project graph")
+            return build()
+        }
+
+        val previousProjects = rawProjects
+        val context = nodesContext()
+        var configChanged = false
+
+        files.forEach { file ->
+            val path = workspaceRoot.resolve(file)
+            if (Path.of(file).fileName.toString() == PROJECT_FILE) {
+                configChanged = true
+                explicitProjects.remove(file)
+                explicitErrors.remove(file)
+                if (path.isRegularFile()) readExplicitProject(path)
+            }
+
+            plugins.forEach { plugin ->
+                if (!inferenceEngine.isConfigFile(workspaceRoot, plugin, file)) return@forEach
+                configChanged = true
+                val results = nodesByPlugin.getOrPut(plugin.metadata.id) { linkedMapOf() }
+                if (path.isRegularFile()) {
+                    results[file] = inferenceEngine.createNodes(plugin, listOf(path.toString()), context)
+                } else {
+                    results.remove(file)
+                }
+            }
+        }
+
+        // Plugins also read the sources of a project, such as //forge: annotations and Go packages,
+        // so re-infer the configuration files of the projects owning a changed file. A file belongs to
+        // the project with the longest root only, so an edit in a nested project leaves its parent alone.
+        val owners = files.mapNotNull { ownerOf(it, rawProjects.values) }.toSet()
+        plugins.forEach { plugin ->
+            val results = nodesByPlugin[plugin.metadata.id] ?: return@forEach
+            val affected = results.filter { (configFile, result) ->
+                configFile !in files && result.projects.keys.any { it in owners }
+            }.keys
+            affected.forEach { configFile ->
+                configChanged = true
+                results[configFile] = inferenceEngine.createNodes(plugin, listOf(workspaceRoot.resolve(configFile).toString()), context)
+            }
+        }
+
+        if (configChanged) {
+            rawProjects = mergeProjects()
+        }
+
+        val changedProjects = rawProjects.keys.filter { rawProjects[it] != previousProjects[it] }.toSet() +
+            (previousProjects.keys - rawProjects.keys)
+        if (changedProjects.isNotEmpty()) {
+            val updated = configuredProjects.filterKeys { it in rawProjects && it !in changedProjects } +
+                discovery.applyWorkspaceDefaults(rawProjects.filterKeys { it in changedProjects }, workspaceConfig)
+            configuredProjects = rawProjects.keys.associateWith { updated.getValue(it) }
+        }
+
+        val dirty = if (rawProjects.keys != previousProjects.keys) {
+            dependenciesBySource.clear()
+            configuredProjects.keys
+        } else {
+            val owners = files.mapNotNull { ownerOf(it) }
+            val dependents = dependenciesBySource.values.flatten()
+                .filter { it.target in changedProjects }
+                .map { it.source }
+            (owners + changedProjects + dependents).filter { it in configuredProjects }.toSet()
+        }
+
+        if (dirty.isNotEmpty()) {
+            resolveDependencies(dirty)
+        }
+
+        graph = assemble()
+        logger.info("Updated project graph for ${files.size} changed file(s): re-resolved ${dirty.size} project(s)")
+        return graph
+    }
+
+    private fun readExplicitProject(projectFile: Path) {
+        val relativeFile = relativize(projectFile)
+        try {
+            val config = discovery.readProjectFile(projectFile)
+            explicitProjects[relativeFile] = config
+        } catch (e: Exception) {
+            logger.warn("Failed to parse project.json at $projectFile: ${e.message}")
+            explicitErrors[relativeFile] = InferenceError(
+                source = "project.json",
+                file = relativeFile,
+                message = e.message ?: "Invalid project.json"
+            )
+        }
+    }
+
+    /**
+     * Combine explicit and inferred projects the way a full discovery does: project.json targets win
+     */
+    private fun mergeProjects(): Map<String, ProjectConfiguration> {
+        val projects = mutableMapOf<String, ProjectConfiguration>()
+        explicitProjects.values.forEach { projects[it.name] = it }
+
+        val inferred = mutableMapOf<String, ProjectConfiguration>()
+        plugins.forEach { plugin ->
+            val results = nodesByPlugin[plugin.metadata.id]?.values ?: emptyList()
+            val pluginProjects = mutableMapOf<String, ProjectConfiguration>()
+            results.forEach { pluginProjects.putAll(it.projects) }
+            inferenceEngine.mergeProjects(inferred, pluginProjects)
+            pluginErrors[plugin.metadata.id] = results.flatMap { it.errors }
+        }
+        projects.putAll(inferred)
+        inferenceEngine.mergeProjects(projects, explicitProjects.values.associateBy { it.name })
+
+        return projects
+    }
+
+    /**
+     * Recompute the outgoing edges of the given projects, keeping every other project's cached edges
+     */
+    private fun resolveDependencies(sources: Set<String>) {
+        val context = CreateDependenciesContext(
+            workspaceRoot = workspaceRoot,
+            projects = configuredProjects,
+            nxJsonConfiguration = workspaceConfig.toMap(),
+            projectsToProcess = sources
+        )
+        val dependencies = inferenceEngine.createDependencies(plugins, context)
+            .filter { it.source in sources }
+            .groupBy { it.source }
+
+        sources.forEach { source -> dependenciesBySource[source] = dependencies[source] ?: emptyList() }
+        dependenciesBySource.keys.retainAll(configuredProjects.keys)
+    }
+
+    private fun assemble(): ProjectGraph {
+        val nodes = configuredProjects.mapValues { (name, config) ->
+            ProjectGraphNode(name, config.projectType, config)
+        }
+        val rawDependencies = configuredProjects.keys.flatMap { dependenciesBySource[it] ?: emptyList() }
+        return ProjectGraph(nodes, discovery.buildDependencyGraph(configuredProjects, rawDependencies))
+    }
+
+    private fun nodesContext() = CreateNodesContext(
+        workspaceRoot = workspaceRoot,
+        nxJsonConfiguration = workspaceConfig.toMap()
+    )
+
+    /**
+     * The project with the longest root containing the file
+     */
+    private fun ownerOf(file: String, projects: Collection<ProjectConfiguration> = configuredProjects.values): String? {
+        return projects
+            .filter { project -> contains(normalize(project.root), file) }
+            .maxByOrNull { normalize(it.root).length }
+            ?.name
+    }
+
+    /**
+     * Whether a workspace-relative file lies inside a directory, where "" is the workspace root
+     */
+    private fun contains(dir: String, file: String): Boolean =
+        dir.isEmpty() || file == dir || file.startsWith("$dir/")
+
+    private fun findProjectFiles(): List<Path> {
+        return Files.walk(workspaceRoot).use { stream ->
+            stream.filter { it.fileName.toString() == PROJECT_FILE && it.isRegularFile() }
+                .toList()
+                .sorted()
+        }
+    }
+
+    private fun relativize(path: Path): String = normalize(workspaceRoot.relativize(path).toString())
+
+    private fun normalize(file: String): String {
+        val path = Path.of(file)
+        val relative = if (path.isAbsolute) workspaceRoot.relativize(path).toString() else file
+        val normalized = relative.replace('\\', '/').removePrefix("./").trimEnd('/')
+        return if (normalized == ".") "" else normalized
+    }
+
+    private fun isIgnored(file: String): Boolean = file.split("/").any { it in IGNORED_DIRECTORIES }
+}
@@ -10,7 +10,9 @@ import com.forge.core.ProjectGraphDependency
 import com.forge.core.ProjectGraphNode
 import com.forge.core.DependencyType
 import com.forge.inference.InferenceEngine
+import com.forge.inference.InferenceError
 import com.forge.inference.InferenceResult
+import com.forge.inference.RawProjectGraphDependency
 import org.slf4j.LoggerFactory
 import java.io.File
 import java.nio.file.Files
@@ -30,15 +32,21 @@ class ProjectDiscovery(
     var workspaceConfiguration: com.forge.config.WorkspaceConfiguration? = null
         private set
     
+    // Projects skipped during the last discovery because their configuration could not be read
+    var inferenceErrors: List<InferenceError> = emptyList()
+        private set
+    
     fun discoverProjects(): ProjectGraph {
         logger.info("Starting project discovery in workspace: $workspaceRoot")
         
         val workspaceConfig = loadWorkspaceConfiguration()
         this.workspaceConfiguration = workspaceConfig // Store for external access
         val projects = mutableMapOf<String, ProjectConfiguration>()
+        val errors = mutableListOf<InferenceError>()
         
         // Discover projects via explicit project.json files
-        projects.putAll(discoverExplicitProjects())
+        val explicitProjects = discoverExplicitProjects(errors)
+        projects.putAll(explicitProjects)
         
         // Discover projects via inference plugins (package.json, etc.)
         var inferenceResult: InferenceResult? = null
@@ -48,8 +56,12 @@ class ProjectDiscovery(
                 workspaceConfig.toMap()
             )
             projects.putAll(inferenceResult.projects)
+            // Targets written in project.json win over inferred and annotated targets of the same name
+            inferenceEngine.mergeProjects(projects, explicitProjects)
+            errors.addAll(inferenceResult.errors)
             logger.info("Inferred ${inferenceResult.projects.size} projects via inference plugins")
         }
+        this.inferenceErrors = errors
         
         // Discover projects via legacy plugins
         plugins.forEach { plugin ->
@@ -65,14 +77,14 @@ class ProjectDiscovery(
         }
         
         // Build dependency graph
-        val dependencies = buildDependencyGraph(configuredProjects, workspaceConfig, inferenceResult)
+        val dependencies = buildDependencyGraph(configuredProjects, inferenceResult?.dependencies ?: emptyList())
         
         logger.info("Discovered ${nodes.size} projects with ${dependencies.values.sumOf { it.size }} dependencies")
         
         return ProjectGraph(nodes, dependencies)
     }
     
-    private fun loadWorkspaceConfiguration(): WorkspaceConfiguration {
+    internal fun loadWorkspaceConfiguration(): WorkspaceConfiguration {
         val forgeConfigPath = workspaceRoot / "forge.json"
         val nxConfigPath = workspaceRoot / "nx.json"
         
@@ -92,30 +104,41 @@ class ProjectDiscovery(
         }
     }
     
-    private fun discoverExplicitProjects(): Map<String, ProjectConfiguration> {
+    private fun discoverExplicitProjects(errors: MutableList<InferenceError>): Map<String, ProjectConfiguration> {
         val projects = mutableMapOf<String, ProjectConfiguration>()
         
         Files.walk(workspaceRoot)
             .filter { it.fileName.toString() == "project.json" }
             .forEach { projectFile ->
                 try {
-                    val configFromFile: ProjectConfiguration = objectMapper.readValue(projectFile.toFile())
-                    
-                    // Infer root from file location (Nx-style)
-                    val projectRoot = workspaceRoot.relativize(projectFile.parent).toString()
-                    val config = configFromFile.copy(root = projectRoot)
-                    
+                    val config = readProjectFile(projectFile)
                     projects[config.name] = config
                     logger.debug("Discovered explicit project: ${config.name} at ${projectFile.parent}")
                 } catch (e: Exception) {
                     logger.warn("Failed to parse project.json at $projectFile: ${e.message}")
+                    errors.add(InferenceError(
+                        source = "project.json",
+                        file = workspaceRoot.relativize(projectFile).toString(),
+                        message = e.message ?: "Invalid project.json"
+                    ))
                 }
             }
         
         return projects
     }
     
-    private fun applyWorkspaceDefaults(
+    /**
+     * Read an explicit project.json, rooting the project at the file's directory
+     */
+    internal fun readProjectFile(projectFile: Path): ProjectConfiguration {
+        val configFromFile: ProjectConfiguration = objectMapper.readValue(projectFile.toFile())
+        
+        // Infer root from file location (Nx-style)
+        val projectRoot = workspaceRoot.relativize(projectFile.parent).toString()
+        return configFromFile.copy(root = projectRoot)
+    }
+    
+    internal fun applyWorkspaceDefaults(
         projects: Map<String, ProjectConfiguration>,
         workspaceConfig: WorkspaceConfiguration
     ): Map<String, ProjectConfiguration> {
@@ -145,14 +168,18 @@ class ProjectDiscovery(
             inputs = if (target.inputs.isEmpty()) defaults.inputs else target.inputs,
             outputs = if (target.outputs.isEmpty()) defaults.outputs else target.outputs,
             cache = if (target.cache != defaults.cache) target.cache else defaults.cache,
-            parallelism = if (target.parallelism != defaults.parallelism) target.parallelism else defaults.parallelism
+            cacheOutputs = target.cacheOutputs ?: defaults.cacheOutputs,
+            cacheLogs = target.cacheLogs ?: defaults.cacheLogs,
+            parallelism = if (target.parallelism != defaults.parallelism) target.parallelism else defaults.parallelism,
+            retry = target.retry ?: defaults.retry,
+            matrix = target.matrix.ifEmpty { defaults.matrix },
+            deprecated = target.deprecated ?: defaults.deprecated
         )
     }
     
-    private fun buildDependencyGraph(
+    internal fun buildDependencyGraph(
         projects: Map<String, ProjectConfiguration>,
-        workspaceConfig: WorkspaceConfiguration,
-        inferenceResult: InferenceResult?
+        rawDependencies: List<RawProjectGraphDependency>
     ): Map<String, List<ProjectGraphDependency>> {
         val dependencies = mutableMapOf<String, MutableList<ProjectGraphDependency>>()
         
@@ -161,7 +188,7 @@ class ProjectDiscovery(
         }
         
         // Add inferred dependencies from plugin inference
-        inferenceResult?.dependencies?.forEach { rawDep ->
+        rawDependencies.forEach { rawDep ->
             if (projects.containsKey(rawDep.source) && projects.containsKey(rawDep.target)) {
                 val dependencyType = rawDep.type
                 
@@ -73,7 +73,16 @@ class ExecutorFactory {
  * Interface that both old TaskExecutor and new RemoteExecutionExecutor can implement
  */
 interface TaskExecutor {
-    fun execute(executionPlan: TaskExecutionPlan, verbose: Boolean = false): ExecutionResults
+    /**
+     * Execute the plan. With skipCached, cache hits are reported as skipped instead of replayed.
+     * The outputs of the tasks in downloadOutputs are on disk afterwards, even when they ran remotely or came from cache.
+     */
+    fun execute(
+        executionPlan: TaskExecutionPlan,
+        verbose: Boolean = false,
+        skipCached: Boolean = false,
+        downloadOutputs: Set<String> = emptySet()
+    ): ExecutionResults
 }
 
 /**
@@ -83,9 +92,14 @@ class UnifiedTaskExecutor(
     private val remoteExecutor: RemoteExecutionExecutor
 ) : TaskExecutor, AutoCloseable {
     
-    override fun execute(executionPlan: TaskExecutionPlan, verbose: Boolean): ExecutionResults {
+    override fun execute(
+        executionPlan: TaskExecutionPlan,
+        verbose: Boolean,
+        skipCached: Boolean,
+        downloadOutputs: Set<String>
+    ): ExecutionResults {
         // RemoteExecutionExecutor.execute has an additional skipCache parameter
-        return remoteExecutor.execute(executionPlan, verbose, skipCache = false)
+        return remoteExecutor.execute(executionPlan, verbose, skipCache = false, skipCached = skipCached, downloadOutputs = downloadOutputs)
     }
     
     override fun close() {
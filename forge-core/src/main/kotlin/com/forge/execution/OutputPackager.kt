@@ -0,0 +1,185 @@
+package com.forge.execution
+
+import com.forge.core.ProjectConfiguration
+import com.forge.core.TargetConfiguration
+import org.slf4j.LoggerFactory
+import java.io.OutputStream
+import java.nio.file.FileSystems
+import java.nio.file.Files
+import java.nio.file.Path
+import java.util.zip.GZIPOutputStream
+import kotlin.io.path.exists
+import kotlin.io.path.isExecutable
+import kotlin.io.path.isRegularFile
+import kotlin.io.path.outputStream
+
+/**
+ * Declared outputs of a target that did not produce any file
+ */
+class MissingOutputsException(
+    val missing: List<String>
+) : Exception("Declared outputs not found: ${missing.joinToString(", ")}")
+
+/**
+ * A file written to an output package
+ */
+data class PackagedFile(
+    val path: String,
+    val size: Long
+)
+
+/**
+ * Collects the declared output files of a target into a gzipped tarball.
+ *
+ * Each output pattern is resolved against the workspace like task inputs:
+ * a plain path selects a file or every file below a directory, a glob selects
+ * the files it matches. Entries are named relative to the project root, or
+ * relative to the workspace root for outputs outside the project. The archive
+ * is plain ustar, so names must be ASCII and files smaller than 8 GiB.
+ */
+class OutputPackager(
+    private val workspaceRoot: Path
+) {
+    private val logger = LoggerFactory.getLogger(OutputPackager::class.java)
+
+    companion object {
+        private const val BLOCK_SIZE = 512
+
+        // The 12-byte size field holds 11 octal digits, so entries must stay below 8 GiB
+        private const val MAX_ENTRY_SIZE = 0x1FFFFFFFFL
+    }
+
+    /**
+     * Resolve the target's outputs to workspace-relative files, failing if any output is missing
+     */
+    fun resolveOutputs(project: ProjectConfiguration, target: TargetConfiguration): List<String> {
+        val files = sortedSetOf<String>()
+        val missing = mutableListOf<String>()
+
+        target.outputs.forEach { output ->
+            val pattern = normalize(
+                output.replace("{workspaceRoot}", "")
+                    .replace("{projectRoot}", project.root)
+                    .replace("{projectName}", project.name)
+            )
+            val matched = resolvePattern(pattern)
+            if (matched.isEmpty()) missing.add(output) else files.addAll(matched)
+        }
+
+        if (missing.isNotEmpty()) {
+            throw MissingOutputsException(missing)
+        }
+        return files.toList()
+    }
+
+    /**
+     * Write the target's outputs to a .tar.gz archive, returning the packaged entries
+     */
+    fun packageOutputs(project: ProjectConfiguration, target: TargetConfiguration, archive: Path): List<PackagedFile> {
+        val files = resolveOutputs(project, target)
+        val projectRoot = normalize(project.root)
+        val entryNames = files.associateWith { file ->
+            if (projectRoot.isNotEmpty() && file.startsWith("$projectRoot/")) file.removePrefix("$projectRoot/") else file
+        }
+        // Check every entry first so an unsupported file leaves no partial archive behind
+        entryNames.forEach { (file, entryName) -> checkStorable(entryName, Files.size(workspaceRoot.resolve(file))) }
+
+        archive.toAbsolutePath().parent?.let { Files.createDirectories(it) }
+        val packaged = GZIPOutputStream(archive.outputStream()).use { gzip ->
+            val entries = entryNames.map { (file, entryName) ->
+                val source = workspaceRoot.resolve(file)
+                val content = Files.readAllBytes(source)
+                writeEntry(gzip, entryName, content, source.isExecutable(), Files.getLastModifiedTime(source).toMillis() / 1000)
+                PackagedFile(entryName, content.size.toLong())
+            }
+            // Two empty blocks mark the end of the archive
+            gzip.write(ByteArray(BLOCK_SIZE * 2))
+            entries
+        }
+
+        logger.info("Packaged ${packaged.size} output file(s) of ${project.name} into $archive")
+        return packaged
+    }
+
+    private fun resolvePattern(pattern: String): List<String> {
+        val literal = pattern.split("/").takeWhile { segment -> segment.none { it in "*?[{" } }.joinToString("/")
+        val base = workspaceRoot.resolve(literal)
+        if (!base.exists()) return emptyList()
+        if (base.isRegularFile()) return listOf(normalize(workspaceRoot.relativize(base).toString()))
+
+        val isGlob = literal != pattern
+        val matchers = if (isGlob) {
+            setOf(pattern, pattern.replace("/**/", "/")).map { FileSystems.getDefault().getPathMatcher("glob:$it") }
+        } else {
+            emptyList()
+        }
+
+        return Files.walk(base).use { stream ->
+            stream.filter { it.isRegularFile() }
+                .map { normalize(workspaceRoot.relativize(it).toString()) }
+                .filter { path -> !isGlob || matchers.any { it.matches(Path.of(path)) } }
+                .toList()
+        }
+    }
+
+    /**
+     * Write a ustar header followed by the file content padded to a whole block
+     */
+    private fun writeEntry(out: OutputStream, name: String, content: ByteArray, executable: Boolean, mtime: Long) {
+        val header = ByteArray(BLOCK_SIZE)
+        val (prefix, shortName) = splitName(name)
+
+        putString(header, 0, 100, shortName)
+        putOctal(header, 100, 8, if (executable) "755".toLong(8) else "644".toLong(8))
+        putOctal(header, 108, 8, 0L)
+        putOctal(header, 116, 8, 0L)
+        putOctal(header, 124, 12, content.size.toLong())
+        putOctal(header, 136, 12, mtime)
+        header[156] = '0'.code.toByte()
+        putString(header, 257, 6, "ustar")
+        putString(header, 263, 2, "00")
+        putString(header, 345, 155, prefix)
+
+        // The checksum is computed with its own field filled with spaces
+        (148 until 156).forEach { header[it] = ' '.code.toByte() }
+        val checksum = header.sumOf { it.toInt() and 0xff }
+        putString(header, 148, 8, "%06o".format(checksum) + "\u0000 ")
+
+        out.write(header)
+        out.write(content)
+        val padding = (BLOCK_SIZE - content.size % BLOCK_SIZE) % BLOCK_SIZE
+        out.write(ByteArray(padding))
+    }
+
+    /**
+     * Reject what plain ustar cannot represent: names that are not ASCII or too long, and files of 8 GiB or more
+     */
+    private fun checkStorable(name: String, size: Long) {
+        require(name.all { it.code < 0x80 }) { "Output path is not ASCII and cannot be packaged: $name" }
+        require(size <= MAX_ENTRY_SIZE) { "Output $name is 8 GiB or larger and cannot be packaged" }
+        splitName(name)
+    }
+
+    /**
+     * Split names longer than the 100-byte name field into a ustar prefix and name
+     */
+    private fun splitName(name: String): Pair<String, String> {
+        if (name.toByteArray().size <= 100) return "" to name
+
+        val split = name.indices.filter { name[it] == '/' }.firstOrNull { index ->
+            name.substring(0, index).toByteArray().size <= 155 && name.substring(index + 1).toByteArray().size <= 100
+        } ?: throw IllegalArgumentException("Output path too long to package: $name")
+        return name.substring(0, split) to name.substring(split + 1)
+    }
+
+    private fun putString(header: ByteArray, offset: Int, length: Int, value: String) {
+        val bytes = value.toByteArray()
+        System.arraycopy(bytes, 0, header, offset, minOf(bytes.size, length))
+    }
+
+    private fun putOctal(header: ByteArray, offset: Int, length: Int, value: Long) {
+        putString(header, offset, length, "%0${length - 1}o".format(value))
+    }
+
+    private fun normalize(path: String): String = path.replace('\\', '/').removePrefix("./").trimStart('/').trimEnd('/')
+}
@@ -0,0 +1,38 @@
+package com.forge.execution
+
+import com.forge.core.TargetDeprecation
+import com.forge.graph.TaskExecutionPlan
+
+/**
+ * A deprecated target about to run, with every project it runs for
+ */
+data class DeprecatedTargetUsage(
+    val targetName: String,
+    val deprecation: TargetDeprecation,
+    val projects: List<String>
+) {
+    /**
+     * Warning pointing at the replacement target, e.g. "Target 'build-all' is deprecated, use 'build' instead"
+     */
+    fun warning(): String = buildString {
+        append("Target '$targetName' is deprecated")
+        deprecation.replacement?.let { append(", use '$it' instead") }
+        deprecation.message?.let { append(": $it") }
+        append(" (${projects.joinToString(", ")})")
+    }
+}
+
+/**
+ * Finds the deprecated targets of an execution plan so each is reported once per run, not once per task
+ */
+object TargetDeprecations {
+    fun find(plan: TaskExecutionPlan): List<DeprecatedTargetUsage> {
+        return plan.getAllTasks()
+            .filter { it.target.isDeprecated() }
+            .groupBy { it.targetName to it.target.deprecated!! }
+            .map { (key, tasks) ->
+                DeprecatedTargetUsage(key.first, key.second, tasks.map { it.projectName }.distinct().sorted())
+            }
+            .sortedBy { it.targetName }
+    }
+}
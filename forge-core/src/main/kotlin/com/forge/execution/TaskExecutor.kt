@@ -17,14 +17,21 @@ import kotlin.io.path.exists
  */
 class LocalTaskExecutor(
     private val workspaceRoot: Path,
-    private val projectGraph: ProjectGraph
+    private val projectGraph: ProjectGraph,
+    private val retry: TaskRetry = TaskRetry()
 ) : TaskExecutor {
     private val logger = LoggerFactory.getLogger(TaskExecutor::class.java)
     
     /**
-     * Execute a task execution plan
+     * Execute a task execution plan. This executor has no cache and writes every output in place,
+     * so skipCached and downloadOutputs have no effect.
      */
-    override fun execute(executionPlan: TaskExecutionPlan, verbose: Boolean): ExecutionResults {
+    override fun execute(
+        executionPlan: TaskExecutionPlan,
+        verbose: Boolean,
+        skipCached: Boolean,
+        downloadOutputs: Set<String>
+    ): ExecutionResults {
         val results = mutableMapOf<String, TaskResult>()
         val startTime = System.currentTimeMillis()
         
@@ -35,7 +42,11 @@ class LocalTaskExecutor(
             
             // Execute tasks in parallel within each layer
             val layerResults = layer.map { task ->
-                executeTask(task, verbose)
+                if (TestSummaries.isAggregate(task)) {
+                    TestSummaries.aggregateResult(task, results, Instant.now())
+                } else {
+                    executeTask(task, verbose)
+                }
             }
             
             // Add results to map
@@ -105,19 +116,23 @@ class LocalTaskExecutor(
             }
             
             // All targets must use run-commands executor
-            val processResult = when (targetConfig.executor) {
+            val outcome = when (targetConfig.executor) {
                 "nx:run-commands", "@nx/run-commands", "forge:run-commands", null -> {
-                    executeRunCommands(targetConfig, task.projectName, projectNode.data.root, verbose)
+                    // The task's own target carries per-task settings such as matrix environment values
+                    retry.run(task.id, task.target.retry) {
+                        executeRunCommands(task.target, task.projectName, projectNode.data.root, verbose)
+                    }
                 }
                 else -> {
                     logger.error("Unsupported executor: ${targetConfig.executor}. Only 'forge:run-commands', 'nx:run-commands', and '@nx/run-commands' are supported.")
-                    ProcessResult(
+                    RetryOutcome(ProcessResult(
                         exitCode = 1,
                         output = "",
                         error = "Unsupported executor: ${targetConfig.executor}"
-                    )
+                    ), attempts = 1)
                 }
             }
+            val processResult = outcome.result
             
             val endTime = System.currentTimeMillis()
             val duration = endTime - startTime
@@ -131,7 +146,8 @@ class LocalTaskExecutor(
                     startTime = Instant.ofEpochMilli(startTime),
                     endTime = endInstant,
                     output = processResult.output,
-                    exitCode = processResult.exitCode
+                    exitCode = processResult.exitCode,
+                    attempts = outcome.attempts
                 )
             } else {
                 logger.error("Task ${task.id} failed with exit code ${processResult.exitCode}")
@@ -145,8 +161,13 @@ class LocalTaskExecutor(
                     startTime = Instant.ofEpochMilli(startTime),
                     endTime = endInstant,
                     output = processResult.output,
-                    error = "Command failed with exit code ${processResult.exitCode}",
-                    exitCode = processResult.exitCode
+                    error = if (outcome.attempts > 1) {
+                        "Command failed with exit code ${processResult.exitCode} after ${outcome.attempts} attempts"
+                    } else {
+                        "Command failed with exit code ${processResult.exitCode}"
+                    },
+                    exitCode = processResult.exitCode,
+                    attempts = outcome.attempts
                 )
             }
             
@@ -60,7 +60,7 @@ class TaskGraphBuilder(
         val taskId = "$projectName:$targetName"
         
         // Skip if task already exists
-        if (tasks.containsKey(taskId)) {
+        if (findTaskIds(taskId, tasks).isNotEmpty()) {
             return
         }
         
@@ -71,15 +71,35 @@ class TaskGraphBuilder(
         
         val target = projectConfig.getTarget(targetName)!!
         
-        // Create the main task
-        tasks[taskId] = Task(
-            id = taskId,
-            projectName = projectName,
-            targetName = targetName,
-            target = target,
-            hash = generateTaskHash(taskId, target, projectConfig)
-        )
-        dependencies[taskId] = mutableListOf()
+        if (target.hasMatrix()) {
+            // One task per matrix entry, labelled by its values and run with them as environment variables
+            target.getMatrixCombinations().forEach { combination ->
+                val label = combination.entries.joinToString(",") { (name, value) -> "$name=$value" }
+                val matrixTaskId = "$taskId[$label]"
+                val env = (target.options["env"] as? Map<*, *> ?: emptyMap<String, String>()) + combination
+                val matrixTarget = target.copy(options = target.options + ("env" to env), matrix = emptyMap())
+                
+                tasks[matrixTaskId] = Task(
+                    id = matrixTaskId,
+                    projectName = projectName,
+                    targetName = targetName,
+                    target = matrixTarget,
+                    hash = generateTaskHash(matrixTaskId, matrixTarget, projectConfig),
+                    matrix = combination
+                )
+                dependencies[matrixTaskId] = mutableListOf()
+            }
+        } else {
+            // Create the main task
+            tasks[taskId] = Task(
+                id = taskId,
+                projectName = projectName,
+                targetName = targetName,
+                target = target,
+                hash = generateTaskHash(taskId, target, projectConfig)
+            )
+            dependencies[taskId] = mutableListOf()
+        }
         
         // Recursively create dependency tasks within the same project
         target.dependsOn.forEach { depTargetName ->
@@ -120,18 +140,14 @@ class TaskGraphBuilder(
                 if (parts.size == 2) {
                     val (projectName, targetName) = parts
                     val resolvedProject = if (projectName == "self") currentTask.projectName else projectName
-                    val taskId = "$resolvedProject:$targetName"
-                    if (allTasks.containsKey(taskId)) listOf(taskId) else emptyList()
+                    findTaskIds("$resolvedProject:$targetName", allTasks)
                 } else {
                     emptyList()
                 }
             }
             
             // target - depends on same target in same project
-            else -> {
-                val taskId = "${currentTask.projectName}:$depString"
-                if (allTasks.containsKey(taskId)) listOf(taskId) else emptyList()
-            }
+            else -> findTaskIds("${currentTask.projectName}:$depString", allTasks)
         }
     }
     
@@ -141,10 +157,15 @@ class TaskGraphBuilder(
         allTasks: Map<String, Task>
     ): List<String> {
         val projectDeps = projectGraph.getDependencies(projectName)
-        return projectDeps.mapNotNull { dep ->
-            val taskId = "${dep.target}:$targetName"
-            if (allTasks.containsKey(taskId)) taskId else null
-        }
+        return projectDeps.flatMap { dep -> findTaskIds("${dep.target}:$targetName", allTasks) }
+    }
+    
+    /**
+     * Task ids for a project target: the task itself, or one per entry of its matrix
+     */
+    private fun findTaskIds(taskId: String, allTasks: Map<String, Task>): List<String> {
+        if (allTasks.containsKey(taskId)) return listOf(taskId)
+        return allTasks.keys.filter { it.startsWith("$taskId[") }.sorted()
     }
     
     fun buildAffectedTaskGraph(
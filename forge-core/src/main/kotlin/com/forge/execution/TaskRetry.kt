@@ -0,0 +1,52 @@
+package com.forge.execution
+
+import com.forge.core.RetryPolicy
+import kotlinx.coroutines.delay
+import kotlinx.coroutines.runBlocking
+import org.slf4j.LoggerFactory
+
+/**
+ * Result of running a task with its retry policy
+ */
+data class RetryOutcome(
+    val result: ProcessResult,
+    val attempts: Int
+)
+
+/**
+ * Re-runs a failed task attempt while its failure matches the target's retry policy
+ */
+class TaskRetry(
+    private val sleep: suspend (Long) -> Unit = { delay(it) }
+) {
+    private val logger = LoggerFactory.getLogger(TaskRetry::class.java)
+
+    /**
+     * Run the attempt, retrying up to maxRetries times on retryable failures only
+     */
+    fun run(taskId: String, policy: RetryPolicy?, attempt: () -> ProcessResult): RetryOutcome =
+        runBlocking { runSuspending(taskId, policy) { attempt() } }
+
+    /**
+     * Same as [run] for attempts that suspend, such as remote executions; waiting between attempts
+     * does not block the other tasks of the layer
+     */
+    suspend fun runSuspending(taskId: String, policy: RetryPolicy?, attempt: suspend () -> ProcessResult): RetryOutcome {
+        var attempts = 1
+        var result = attempt()
+
+        while (policy != null && attempts <= policy.maxRetries &&
+            policy.isRetryable(result.exitCode, "${result.output}\n${result.error}")) {
+            logger.warn("Task $taskId failed with retryable exit code ${result.exitCode}, retry $attempts of ${policy.maxRetries}")
+            if (policy.delayMillis > 0) sleep(policy.delayMillis)
+            attempts++
+            result = attempt()
+        }
+
+        if (result.exitCode != 0 && attempts == 1 && policy != null && policy.maxRetries > 0) {
+            logger.info("Task $taskId failed with non-retryable exit code ${result.exitCode}, not retrying")
+        }
+
+        return RetryOutcome(result, attempts)
+    }
+}
@@ -0,0 +1,104 @@
+package com.forge.execution
+
+import com.forge.core.TargetConfiguration
+import com.forge.graph.Task
+import com.forge.graph.TaskExecutionPlan
+import com.forge.graph.TaskResult
+import com.forge.graph.TaskStatus
+import java.time.Instant
+
+/**
+ * Merged results of the package test tasks an aggregate test target depends on
+ */
+data class TestSummary(
+    val projectName: String,
+    val targetName: String,
+    val packageTaskIds: List<String>,
+    val results: List<TaskResult>
+) {
+    val passed: Int get() = results.count { it.isSuccess }
+    val failed: Int get() = results.count { it.isFailure }
+    val cached: Int get() = results.count { it.wasCached() }
+    val notRun: Int get() = packageTaskIds.size - results.size
+
+    val success: Boolean get() = failed == 0 && notRun == 0
+
+    /**
+     * Totals line, e.g. "4 package(s): 3 passed (2 cached), 1 failed"
+     */
+    fun headline(): String = buildString {
+        append("${packageTaskIds.size} package(s): $passed passed")
+        if (cached > 0) append(" ($cached cached)")
+        if (failed > 0) append(", $failed failed")
+        if (notRun > 0) append(", $notRun not run")
+    }
+
+    /**
+     * Headline followed by one line per package, with the output of failed packages
+     */
+    fun render(): String = buildString {
+        appendLine(headline())
+        results.sortedBy { it.task.id }.forEach { result ->
+            val state = when {
+                result.isFailure -> "FAIL"
+                result.wasCached() -> "ok (cached)"
+                else -> "ok"
+            }
+            appendLine("  $state ${result.task.targetName} ${result.duration}ms")
+            if (result.isFailure) {
+                listOf(result.output, result.error).filter { it.isNotBlank() }.forEach { text ->
+                    text.trimEnd().lines().forEach { appendLine("      $it") }
+                }
+            }
+        }
+    }.trimEnd()
+}
+
+/**
+ * Builds project test summaries from the results of per-package test tasks, such as the
+ * `test/<package>` targets of a Go module with splitTestsByPackage.
+ */
+object TestSummaries {
+    fun isAggregate(task: Task): Boolean = task.target.executor == TargetConfiguration.TEST_SUMMARY_EXECUTOR
+
+    /**
+     * Summarize the package tasks of an aggregate task from the results gathered so far
+     */
+    fun summarize(task: Task, results: Map<String, TaskResult>): TestSummary {
+        val packageTaskIds = task.target.dependsOn
+            .filterNot { it.startsWith("^") }
+            .map { dependency -> if (dependency.contains(":")) dependency else "${task.projectName}:$dependency" }
+        return TestSummary(
+            projectName = task.projectName,
+            targetName = task.targetName,
+            packageTaskIds = packageTaskIds,
+            results = packageTaskIds.mapNotNull { results[it] }
+        )
+    }
+
+    /**
+     * Summaries for every aggregate task of the plan, including those that never ran because a package failed
+     */
+    fun find(plan: TaskExecutionPlan, results: ExecutionResults): List<TestSummary> {
+        return plan.getAllTasks()
+            .filter { isAggregate(it) }
+            .map { summarize(it, results.results) }
+            .sortedBy { "${it.projectName}:${it.targetName}" }
+    }
+
+    /**
+     * The result of running an aggregate task: failed unless every package task succeeded
+     */
+    fun aggregateResult(task: Task, results: Map<String, TaskResult>, startTime: Instant): TaskResult {
+        val summary = summarize(task, results)
+        return TaskResult(
+            task = task,
+            status = if (summary.success) TaskStatus.COMPLETED else TaskStatus.FAILED,
+            startTime = startTime,
+            endTime = Instant.now(),
+            output = summary.render(),
+            error = if (summary.success) "" else "Package tests failed: ${summary.headline()}",
+            exitCode = if (summary.success) 0 else 1
+        )
+    }
+}
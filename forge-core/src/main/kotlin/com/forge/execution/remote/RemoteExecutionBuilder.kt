@@ -23,11 +23,19 @@ class RemoteExecutionBuilder(
 ) {
     private val logger = LoggerFactory.getLogger(RemoteExecutionBuilder::class.java)
     
+    companion object {
+        /**
+         * Environment variable carrying the digest of the task's resolved input files
+         */
+        const val INPUT_DIGEST_ENV = "FORGE_INPUT_DIGEST"
+    }
+    
     /**
-     * Build a Remote Execution Action from a Forge task
+     * Build a Remote Execution Action from a Forge task.
+     * The input digest, when given, ties the action to the current content of the task's input files.
      */
-    fun buildAction(task: Task, projectRoot: String): Action {
-        val command = buildCommand(task, projectRoot)
+    fun buildAction(task: Task, projectRoot: String, inputDigest: String? = null): Action {
+        val command = buildCommand(task, projectRoot, inputDigest)
         val commandDigest = computeDigest(command.toByteArray())
         
         val inputRoot = buildInputRoot(task, projectRoot)
@@ -44,7 +52,7 @@ class RemoteExecutionBuilder(
     /**
      * Build a Command from a Forge task
      */
-    fun buildCommand(task: Task, projectRoot: String): Command {
+    fun buildCommand(task: Task, projectRoot: String, inputDigest: String? = null): Command {
         val commandBuilder = Command.newBuilder()
         
         // Extract commands from target configuration
@@ -80,6 +88,15 @@ class RemoteExecutionBuilder(
             }
         }
         
+        if (inputDigest != null) {
+            commandBuilder.addEnvironmentVariables(
+                Command.EnvironmentVariable.newBuilder()
+                    .setName(INPUT_DIGEST_ENV)
+                    .setValue(inputDigest)
+                    .build()
+            )
+        }
+        
         // Add output paths from target configuration
         task.target.outputs.forEach { output ->
             val resolvedOutput = resolvePathPattern(output, task.projectName, projectRoot)
@@ -138,8 +155,13 @@ class RemoteExecutionBuilder(
     /**
      * Build ExecuteRequest for a task
      */
-    fun buildExecuteRequest(task: Task, projectRoot: String, skipCacheLookup: Boolean = false): ExecuteRequest {
-        val action = buildAction(task, projectRoot)
+    fun buildExecuteRequest(
+        task: Task,
+        projectRoot: String,
+        skipCacheLookup: Boolean = false,
+        inputDigest: String? = null
+    ): ExecuteRequest {
+        val action = buildAction(task, projectRoot, inputDigest)
         val actionDigest = computeDigest(action.toByteArray())
         
         return ExecuteRequest.newBuilder()
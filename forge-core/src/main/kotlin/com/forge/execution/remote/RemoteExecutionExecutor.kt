@@ -1,8 +1,14 @@
 package com.forge.execution.remote
 
 import build.bazel.remote.execution.v2.*
+import com.forge.cache.InputSnapshot
+import com.forge.cache.InputSnapshotStore
+import com.forge.cache.TaskInputResolver
 import com.forge.core.ProjectGraph
 import com.forge.execution.ExecutionResults
+import com.forge.execution.ProcessResult
+import com.forge.execution.TaskRetry
+import com.forge.execution.TestSummaries
 import com.forge.graph.Task
 import com.forge.graph.TaskExecutionPlan
 import com.forge.graph.TaskResult
@@ -14,6 +20,7 @@ import kotlinx.coroutines.async
 import kotlinx.coroutines.flow.last
 import kotlinx.coroutines.runBlocking
 import org.slf4j.LoggerFactory
+import java.nio.file.Files
 import java.nio.file.Path
 import java.time.Instant
 
@@ -24,16 +31,29 @@ import java.time.Instant
 class RemoteExecutionExecutor(
     private val workspaceRoot: Path,
     private val projectGraph: ProjectGraph,
-    private val config: RemoteExecutionConfig
+    private val config: RemoteExecutionConfig,
+    private val services: RemoteExecutionServices = RemoteExecutionServiceFactory.create(config),
+    private val retry: TaskRetry = TaskRetry()
 ) {
     private val logger = LoggerFactory.getLogger(RemoteExecutionExecutor::class.java)
-    private val services = RemoteExecutionServiceFactory.create(config)
     private val builder = RemoteExecutionBuilder(workspaceRoot, config.instanceName)
+    private val inputResolver = TaskInputResolver.forWorkspace(workspaceRoot, projectGraph)
+    private val snapshotStore = InputSnapshotStore(workspaceRoot)
     
     /**
-     * Execute a task execution plan using Remote Execution API
+     * Execute a task execution plan using Remote Execution API.
+     * skipCache bypasses the action cache; skipCached runs only cache misses and reports hits as skipped,
+     * restoring the outputs of skipped tasks that other tasks of the plan depend on.
+     * The outputs of the tasks in downloadOutputs are written to the workspace, whether they ran remotely
+     * or came from cache, so they can be packaged.
      */
-    fun execute(executionPlan: TaskExecutionPlan, verbose: Boolean = false, skipCache: Boolean = false): ExecutionResults = runBlocking {
+    fun execute(
+        executionPlan: TaskExecutionPlan,
+        verbose: Boolean = false,
+        skipCache: Boolean = false,
+        skipCached: Boolean = false,
+        downloadOutputs: Set<String> = emptySet()
+    ): ExecutionResults = runBlocking {
         val results = mutableMapOf<String, TaskResult>()
         val startTime = System.currentTimeMillis()
         
@@ -43,8 +63,12 @@ class RemoteExecutionExecutor(
             logger.info("Executing layer ${layerIndex + 1} with ${layer.size} task(s)")
             
             // Execute tasks in parallel within each layer using async
+            val completed = results.toMap()
             val layerResults = layer.map { task ->
-                async { executeTask(task, verbose, skipCache) }
+                async {
+                    executeTask(task, verbose, skipCache, skipCached, task.id in downloadOutputs,
+                        executionPlan.hasDependents(task.id), completed)
+                }
             }.map { it.await() }
             
             // Add results to map
@@ -81,12 +105,25 @@ class RemoteExecutionExecutor(
     }
     
     /**
-     * Execute a single task using Remote Execution API
+     * Execute a single task using Remote Execution API.
+     * Aggregate test tasks run locally, merging the results of earlier layers.
      */
-    private suspend fun executeTask(task: Task, verbose: Boolean, skipCache: Boolean): TaskResult {
+    private suspend fun executeTask(
+        task: Task,
+        verbose: Boolean,
+        skipCache: Boolean,
+        skipCached: Boolean,
+        downloadOutputs: Boolean,
+        hasDependents: Boolean,
+        completed: Map<String, TaskResult>
+    ): TaskResult {
         val startTime = System.currentTimeMillis()
         val startInstant = Instant.ofEpochMilli(startTime)
         
+        if (TestSummaries.isAggregate(task)) {
+            return TestSummaries.aggregateResult(task, completed, startInstant)
+        }
+        
         try {
             logger.info("Executing remote task: ${task.id}")
             
@@ -102,44 +139,75 @@ class RemoteExecutionExecutor(
                 )
             }
             
+            // Hash the resolved input files so content changes produce a new action digest
+            val inputSnapshot = if (task.target.isCacheable()) captureInputSnapshot(task) else null
+            
             // Build the remote execution request
             val executeRequest = builder.buildExecuteRequest(
                 task = task,
                 projectRoot = projectNode.data.root,
-                skipCacheLookup = skipCache
+                skipCacheLookup = skipCache,
+                inputDigest = inputSnapshot?.key
             )
             
             // Check action cache first (unless skipping cache)
             if (!skipCache && task.target.isCacheable()) {
                 val cachedResult = checkActionCache(executeRequest.actionDigest)
-                if (cachedResult != null) {
-                    logger.info("Task ${task.id} found in cache")
-                    return TaskResult(
-                        task = task,
-                        status = TaskStatus.CACHED,
-                        startTime = startInstant,
-                        endTime = Instant.now(),
-                        output = "Cached result",
-                        fromCache = true
-                    )
+                // A dependent that misses the cache builds against this task's outputs, so they are restored
+                // before skipping; when they cannot be fetched the task runs instead
+                if (cachedResult != null && skipCached) {
+                    val restoredOutputs = if (hasDependents || downloadOutputs) restoreOutputs(task, cachedResult) else 0
+                    if (restoredOutputs != null) {
+                        logger.info("Task ${task.id} found in cache, skipping")
+                        return TaskResult(
+                            task = task,
+                            status = TaskStatus.SKIPPED,
+                            startTime = startInstant,
+                            endTime = Instant.now(),
+                            output = "Skipped (cache hit)",
+                            fromCache = true
+                        )
+                    }
+                }
+                // Outputs are only restored when the target opts in or the run packages them, and logs only
+                // replayed when the target opts in. Outputs that cannot be fetched from CAS are rebuilt by running the task.
+                if (cachedResult != null && !skipCached) {
+                    val restoredOutputs = if (task.target.shouldCacheOutputs() || downloadOutputs) restoreOutputs(task, cachedResult) else 0
+                    if (restoredOutputs != null) {
+                        logger.info("Task ${task.id} found in cache")
+                        val logs = if (task.target.shouldCacheLogs()) readLogs(cachedResult) else null
+                        val output = logs ?: "Cached result"
+                        return TaskResult(
+                            task = task,
+                            status = TaskStatus.CACHED,
+                            startTime = startInstant,
+                            endTime = Instant.now(),
+                            output = output,
+                            fromCache = true
+                        )
+                    }
                 }
             }
             
             // Upload blobs to CAS before execution
             logger.info("Uploading action blobs to CAS for task: ${task.id}")
-            uploadActionBlobs(task, projectNode.data.root, executeRequest.actionDigest)
+            uploadActionBlobs(task, projectNode.data.root, executeRequest.actionDigest, inputSnapshot?.key)
             
-            // Execute remotely
-            val operation = services.execution.execute(executeRequest).last()
-            
-            if (!operation.done) {
-                logger.error("Remote execution did not complete: ${task.id}")
+            // Execute remotely, retrying failures the target's retry policy allows
+            var result = ActionResult.getDefaultInstance()
+            val outcome = try {
+                retry.runSuspending(task.id, task.target.retry) {
+                    result = executeRemotely(executeRequest)
+                    ProcessResult(result.exitCode, readLogs(result) ?: "", "")
+                }
+            } catch (e: IncompleteOperationException) {
+                logger.error("${e.message}: ${task.id}")
                 return TaskResult(
                     task = task,
                     status = TaskStatus.FAILED,
                     startTime = startInstant,
                     endTime = Instant.now(),
-                    error = "Remote execution did not complete"
+                    error = e.message ?: "Remote execution failed"
                 )
             }
             
@@ -147,27 +215,26 @@ class RemoteExecutionExecutor(
             val endInstant = Instant.now()
             val duration = endTime - startTime
             
-            if (operation.hasError()) {
-                logger.error("Remote execution failed for task ${task.id}: ${operation.error}")
-                return TaskResult(
-                    task = task,
-                    status = TaskStatus.FAILED,
-                    startTime = startInstant,
-                    endTime = endInstant,
-                    error = "Remote execution failed: ${operation.error.message}"
-                )
-            }
-            
-            // Parse the execution response
-            val response = operation.response.unpack(ExecuteResponse::class.java)
-            val result = response.result
-            
             if (result.exitCode == 0) {
                 logger.info("Remote task ${task.id} completed successfully in ${duration}ms")
                 
+                // The outputs were written on the worker; download them when the run packages them
+                if (downloadOutputs && restoreOutputs(task, result) == null) {
+                    return TaskResult(
+                        task = task,
+                        status = TaskStatus.FAILED,
+                        startTime = startInstant,
+                        endTime = endInstant,
+                        output = extractOutput(result),
+                        error = "Failed to download the outputs of ${task.id}",
+                        attempts = outcome.attempts
+                    )
+                }
+                
                 // Cache the result if caching is enabled
                 if (task.target.isCacheable()) {
-                    cacheActionResult(executeRequest.actionDigest, result)
+                    cacheActionResult(executeRequest.actionDigest, cacheableResult(task, result))
+                    inputSnapshot?.let { recordInputSnapshot(it) }
                 }
                 
                 return TaskResult(
@@ -176,7 +243,8 @@ class RemoteExecutionExecutor(
                     startTime = startInstant,
                     endTime = endInstant,
                     output = extractOutput(result),
-                    exitCode = result.exitCode
+                    exitCode = result.exitCode,
+                    attempts = outcome.attempts
                 )
             } else {
                 logger.error("Remote task ${task.id} failed with exit code ${result.exitCode}")
@@ -186,8 +254,13 @@ class RemoteExecutionExecutor(
                     startTime = startInstant,
                     endTime = endInstant,
                     output = extractOutput(result),
-                    error = "Command failed with exit code ${result.exitCode}",
-                    exitCode = result.exitCode
+                    error = if (outcome.attempts > 1) {
+                        "Command failed with exit code ${result.exitCode} after ${outcome.attempts} attempts"
+                    } else {
+                        "Command failed with exit code ${result.exitCode}"
+                    },
+                    exitCode = result.exitCode,
+                    attempts = outcome.attempts
                 )
             }
             
@@ -218,6 +291,20 @@ class RemoteExecutionExecutor(
         }
     }
     
+    /**
+     * Run one attempt of an action, failing when the operation itself did not produce a result
+     */
+    private suspend fun executeRemotely(executeRequest: ExecuteRequest): ActionResult {
+        val operation = services.execution.execute(executeRequest).last()
+        if (!operation.done) {
+            throw IncompleteOperationException("Remote execution did not complete")
+        }
+        if (operation.hasError()) {
+            throw IncompleteOperationException("Remote execution failed: ${operation.error.message}")
+        }
+        return operation.response.unpack(ExecuteResponse::class.java).result
+    }
+    
     /**
      * Check if an action result is cached
      */
@@ -253,6 +340,119 @@ class RemoteExecutionExecutor(
         }
     }
     
+    /**
+     * Strip the parts of an action result the target explicitly does not want cached
+     */
+    private fun cacheableResult(task: Task, result: ActionResult): ActionResult {
+        if (task.target.cacheOutputs != false && task.target.cacheLogs != false) return result
+        
+        val builder = result.toBuilder()
+        if (task.target.cacheOutputs == false) {
+            builder.clearOutputFiles().clearOutputDirectories().clearOutputSymlinks()
+        }
+        if (task.target.cacheLogs == false) {
+            builder.clearStdoutRaw().clearStdoutDigest().clearStderrRaw().clearStderrDigest()
+        }
+        return builder.build()
+    }
+    
+    /**
+     * Write the output files of an action result into the workspace.
+     * Returns the number of files restored, or null when they could not be fetched.
+     */
+    private suspend fun restoreOutputs(task: Task, result: ActionResult): Int? {
+        if (result.outputFilesCount == 0) return 0
+        
+        return try {
+            val missing = result.outputFilesList.filter { it.contents.isEmpty && it.digest.sizeBytes > 0 }
+            val blobs = readBlobs(missing.map { it.digest })
+            
+            result.outputFilesList.forEach { outputFile ->
+                val content = when {
+                    !outputFile.contents.isEmpty -> outputFile.contents
+                    outputFile.digest.sizeBytes == 0L -> ByteString.EMPTY
+                    else -> blobs[outputFile.digest.hash]
+                        ?: throw RemoteExecutionException("Output ${outputFile.path} is missing from CAS")
+                }
+                val target = workspaceRoot.resolve(outputFile.path)
+                Files.createDirectories(target.parent)
+                Files.write(target, content.toByteArray())
+                if (outputFile.isExecutable) {
+                    target.toFile().setExecutable(true)
+                }
+            }
+            
+            logger.debug("Restored ${result.outputFilesCount} output file(s) for ${task.id}")
+            result.outputFilesCount
+        } catch (e: Exception) {
+            logger.warn("Failed to restore outputs for ${task.id}: ${e.message}")
+            null
+        }
+    }
+    
+    /**
+     * Stdout and stderr of a cached run, inline or from CAS, or null when none were captured
+     */
+    private suspend fun readLogs(result: ActionResult): String? {
+        val digests = listOfNotNull(
+            result.stdoutDigest.takeIf { result.hasStdoutDigest() && result.stdoutRaw.isEmpty },
+            result.stderrDigest.takeIf { result.hasStderrDigest() && result.stderrRaw.isEmpty }
+        )
+        val blobs = try {
+            readBlobs(digests)
+        } catch (e: Exception) {
+            logger.warn("Failed to read cached logs: ${e.message}")
+            emptyMap()
+        }
+        
+        val stdout = result.stdoutRaw.takeUnless { it.isEmpty } ?: blobs[result.stdoutDigest.hash]
+        val stderr = result.stderrRaw.takeUnless { it.isEmpty } ?: blobs[result.stderrDigest.hash]
+        val logs = listOfNotNull(stdout, stderr)
+            .map { it.toStringUtf8().trimEnd() }
+            .filter { it.isNotEmpty() }
+        
+        return logs.joinToString("\n").ifEmpty { null }
+    }
+    
+    /**
+     * Download blobs from CAS, keyed by digest hash
+     */
+    private suspend fun readBlobs(digests: List<Digest>): Map<String, ByteString> {
+        if (digests.isEmpty()) return emptyMap()
+        
+        val request = BatchReadBlobsRequest.newBuilder()
+            .setInstanceName(config.instanceName)
+            .addAllDigests(digests.distinctBy { it.hash })
+            .build()
+        
+        return services.cas.batchReadBlobs(request).responsesList
+            .filter { it.status.code == 0 }
+            .associate { it.digest.hash to it.data }
+    }
+    
+    /**
+     * Hash the resolved input files of a task, or null when they cannot be read
+     */
+    private fun captureInputSnapshot(task: Task): InputSnapshot? {
+        return try {
+            inputResolver.snapshot(task)
+        } catch (e: Exception) {
+            logger.warn("Failed to hash inputs for ${task.id}: ${e.message}")
+            null
+        }
+    }
+    
+    /**
+     * Record the input files of a cached task so cache misses can be explained later
+     */
+    private fun recordInputSnapshot(snapshot: InputSnapshot) {
+        try {
+            snapshotStore.save(snapshot)
+        } catch (e: Exception) {
+            logger.warn("Failed to record input snapshot for ${snapshot.taskId}: ${e.message}")
+        }
+    }
+    
     /**
      * Extract output from action result
      */
@@ -277,11 +477,11 @@ class RemoteExecutionExecutor(
     /**
      * Upload Action, Command, and Directory blobs to Content Addressable Storage
      */
-    private suspend fun uploadActionBlobs(task: Task, projectRoot: String, actionDigest: Digest) {
+    private suspend fun uploadActionBlobs(task: Task, projectRoot: String, actionDigest: Digest, inputDigest: String?) {
         try {
             // Build the action and its components
-            val action = builder.buildAction(task, projectRoot)
-            val command = builder.buildCommand(task, projectRoot)
+            val action = builder.buildAction(task, projectRoot, inputDigest)
+            val command = builder.buildCommand(task, projectRoot, inputDigest)
             val inputRoot = builder.buildInputRoot(task, projectRoot)
             
             // Create blobs to upload
@@ -343,4 +543,9 @@ class RemoteExecutionExecutor(
     fun close() {
         services.close()
     }
-}
\ No newline at end of file
+}
+
+/**
+ * An execute operation that finished without an action result; its message is the task's error
+ */
+private class IncompleteOperationException(message: String) : Exception(message)
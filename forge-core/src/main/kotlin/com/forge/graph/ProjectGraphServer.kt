@@ -0,0 +1,142 @@
+package com.forge.graph
+
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.forge.core.ProjectGraph
+import com.sun.net.httpserver.HttpExchange
+import com.sun.net.httpserver.HttpServer
+import org.slf4j.LoggerFactory
+import java.net.InetSocketAddress
+import java.util.concurrent.ExecutorService
+import java.util.concurrent.Executors
+import java.util.concurrent.atomic.AtomicLong
+
+/**
+ * Serves the project graph over HTTP for the browser viewer.
+ *
+ * `GET /` returns the single-page viewer, `GET /api/graph` the current graph
+ * as JSON and `GET /api/graph/version` a counter that increases each time the
+ * graph is replaced, which the viewer polls to refresh itself.
+ */
+class ProjectGraphServer(
+    initialGraph: ProjectGraph,
+    private val port: Int = DEFAULT_PORT,
+    private val host: String = "127.0.0.1"
+) : AutoCloseable {
+    private val logger = LoggerFactory.getLogger(ProjectGraphServer::class.java)
+    private val mapper = jacksonObjectMapper()
+    private val version = AtomicLong(1)
+
+    @Volatile
+    private var projectGraph: ProjectGraph = initialGraph
+    private var server: HttpServer? = null
+
+    companion object {
+        const val DEFAULT_PORT = 4211
+        private const val VIEWER_RESOURCE = "graph-viewer/index.html"
+
+        /**
+         * JSON view of a project graph: projects with their targets and a flat list of dependency edges
+         */
+        fun toJson(projectGraph: ProjectGraph, version: Long): Map<String, Any?> = mapOf(
+            "version" to version,
+            "projects" to projectGraph.getAllProjects().sortedBy { it.name }.map { project ->
+                mapOf(
+                    "name" to project.name,
+                    "type" to project.data.projectType,
+                    "root" to project.data.root,
+                    "tags" to project.data.tags,
+                    "targets" to project.data.targets.keys.sorted()
+                )
+            },
+            "dependencies" to projectGraph.dependencies.toSortedMap().flatMap { (_, dependencies) ->
+                dependencies.map { dependency ->
+                    mapOf(
+                        "source" to dependency.source,
+                        "target" to dependency.target,
+                        "type" to dependency.type.toString().lowercase()
+                    )
+                }
+            }
+        )
+    }
+
+    /**
+     * Start serving, returning the bound port (useful when started on port 0)
+     */
+    fun start(): Int {
+        val httpServer = HttpServer.create(InetSocketAddress(host, port), 0)
+        httpServer.createContext("/api/graph/version") { exchange ->
+            handle(exchange) { respondJson(exchange, mapOf("version" to version.get())) }
+        }
+        httpServer.createContext("/api/graph") { exchange ->
+            handle(exchange) { respondJson(exchange, toJson(projectGraph, version.get())) }
+        }
+        httpServer.createContext("/") { exchange ->
+            handle(exchange) {
+                if (exchange.requestURI.path != "/" && exchange.requestURI.path != "/index.html") {
+                    respond(exchange, 404, "text/plain", "Not found".toByteArray())
+                } else {
+                    respond(exchange, 200, "text/html; charset=utf-8", loadViewer())
+                }
+            }
+        }
+        httpServer.executor = Executors.newFixedThreadPool(4) { runnable ->
+            Thread(runnable, "forge-graph-server").apply { isDaemon = true }
+        }
+        httpServer.start()
+        server = httpServer
+
+        val boundPort = httpServer.address.port
+        logger.info("Project graph server listening on http://$host:$boundPort")
+        return boundPort
+    }
+
+    /**
+     * Replace the served graph; connected viewers pick it up on their next poll
+     */
+    fun update(projectGraph: ProjectGraph) {
+        this.projectGraph = projectGraph
+        version.incrementAndGet()
+    }
+
+    override fun close() {
+        server?.let { httpServer ->
+            httpServer.stop(0)
+            (httpServer.executor as? ExecutorService)?.shutdownNow()
+            logger.info("Project graph server stopped")
+        }
+        server = null
+    }
+
+    private fun handle(exchange: HttpExchange, block: () -> Unit) {
+        try {
+            if (exchange.requestMethod != "GET") {
+                respond(exchange, 405, "text/plain", "Method not allowed".toByteArray())
+            } else {
+                block()
+            }
+        } catch (e: Exception) {
+            logger.error("Error serving ${exchange.requestURI}: ${e.message}", e)
+            respond(exchange, 500, "text/plain", (e.message ?: "Internal error").toByteArray())
+        } finally {
+            exchange.close()
+        }
+    }
+
+    private fun respondJson(exchange: HttpExchange, body: Any) {
+        respond(exchange, 200, "application/json", mapper.writeValueAsBytes(body))
+    }
+
+    private fun respond(exchange: HttpExchange, status: Int, contentType: String, body: ByteArray) {
+        exchange.responseHeaders.set("Content-Type", contentType)
+        exchange.responseHeaders.set("Cache-Control", "no-store")
+        exchange.sendResponseHeaders(status, body.size.toLong())
+        exchange.responseBody.use { it.write(body) }
+    }
+
+    private fun loadViewer(): ByteArray {
+        val stream = ProjectGraphServer::class.java.classLoader.getResourceAsStream(VIEWER_RESOURCE)
+            ?: throw IllegalStateException("Graph viewer resource $VIEWER_RESOURCE not found")
+        return stream.use { it.readBytes() }
+    }
+}
@@ -69,7 +69,7 @@ data class TaskGraph(
         val layerTasks = layers.map { layer -> 
             layer.mapNotNull { taskId -> tasks[taskId] } 
         }
-        return TaskExecutionPlan(layerTasks)
+        return TaskExecutionPlan(layerTasks, dependencies)
     }
 }
 
@@ -80,7 +80,8 @@ data class Task(
     val target: TargetConfiguration,
     val hash: String? = null,
     val startTime: Instant? = null,
-    val endTime: Instant? = null
+    val endTime: Instant? = null,
+    val matrix: Map<String, String> = emptyMap()
 ) {
     val status: TaskStatus = when {
         startTime == null -> TaskStatus.PENDING
@@ -117,7 +118,8 @@ enum class TaskStatus {
 }
 
 data class TaskExecutionPlan(
-    val layers: List<List<Task>>
+    val layers: List<List<Task>>,
+    val dependencies: Map<String, List<String>> = emptyMap()
 ) {
     val totalTasks: Int = layers.sumOf { it.size }
     
@@ -131,6 +133,8 @@ data class TaskExecutionPlan(
     fun getAllTasks(): List<Task> = layers.flatten()
     
     fun isEmpty(): Boolean = layers.isEmpty() || layers.all { it.isEmpty() }
+    
+    fun hasDependents(taskId: String): Boolean = dependencies.values.any { it.contains(taskId) }
 }
 
 data class TaskResult(
@@ -141,11 +145,12 @@ data class TaskResult(
     val output: String = "",
     val error: String = "",
     val exitCode: Int = 0,
-    val fromCache: Boolean = false
+    val fromCache: Boolean = false,
+    val attempts: Int = 1
 ) {
     val duration: Long = endTime.toEpochMilli() - startTime.toEpochMilli()
     
-    val isSuccess: Boolean = status == TaskStatus.COMPLETED || status == TaskStatus.CACHED
+    val isSuccess: Boolean = status == TaskStatus.COMPLETED || status == TaskStatus.CACHED || status == TaskStatus.SKIPPED
     
     val isFailure: Boolean = status == TaskStatus.FAILED
     
@@ -0,0 +1,92 @@
+package com.forge.inference
+
+/**
+ * An import declared in a Go source file
+ */
+data class GoImport(
+    val path: String,
+    val line: Int
+)
+
+/**
+ * The package clause of a Go source file, with its optional canonical import comment
+ */
+data class GoPackageClause(
+    val name: String,
+    val line: Int,
+    val canonicalImport: String? = null
+)
+
+/**
+ * Lightweight line-based parsing of go.mod files and Go source headers
+ */
+object GoSource {
+    private val moduleRegex = Regex("""^module\s+(\S+)""")
+    private val requireRegex = Regex("""^require\s+(\S+)\s+\S+""")
+    private val requireEntryRegex = Regex("""^(\S+)\s+\S+""")
+    private val packageRegex = Regex("""^package\s+(\w+)(?:\s*//\s*import\s+"([^"]+)")?""")
+    private val singleImportRegex = Regex("""^import\s+(?:[\w.]+\s+)?"([^"]+)"""")
+    private val quotedPathRegex = Regex("""^(?:[\w.]+\s+)?"([^"]+)"""")
+
+    /**
+     * Module path declared in a go.mod file
+     */
+    fun modulePath(goModContent: String): String? =
+        goModContent.lines().firstNotNullOfOrNull { moduleRegex.find(it.trim())?.groupValues?.get(1) }
+
+    /**
+     * Module paths listed in the require directives of a go.mod file
+     */
+    fun requiredModules(goModContent: String): Set<String> {
+        val modules = mutableSetOf<String>()
+        var inRequireBlock = false
+
+        goModContent.lines().forEach { rawLine ->
+            val line = rawLine.substringBefore("//").trim()
+            when {
+                inRequireBlock && line.startsWith(")") -> inRequireBlock = false
+                inRequireBlock -> requireEntryRegex.find(line)?.let { modules.add(it.groupValues[1]) }
+                line.startsWith("require (") || line == "require(" -> inRequireBlock = true
+                else -> requireRegex.find(line)?.let { modules.add(it.groupValues[1]) }
+            }
+        }
+
+        return modules
+    }
+
+    /**
+     * Package clause of a Go source file
+     */
+    fun packageClause(content: String): GoPackageClause? {
+        content.lines().forEachIndexed { index, line ->
+            packageRegex.find(line.trim())?.let { match ->
+                return GoPackageClause(
+                    name = match.groupValues[1],
+                    line = index + 1,
+                    canonicalImport = match.groupValues[2].ifEmpty { null }
+                )
+            }
+        }
+        return null
+    }
+
+    /**
+     * Imports declared in a Go source file, with their line numbers
+     */
+    fun imports(content: String): List<GoImport> {
+        val imports = mutableListOf<GoImport>()
+        var inImportBlock = false
+
+        content.lines().forEachIndexed { index, rawLine ->
+            val line = rawLine.substringBefore("//").trim()
+            when {
+                inImportBlock && line.startsWith(")") -> inImportBlock = false
+                inImportBlock -> quotedPathRegex.find(line)?.let { imports.add(GoImport(it.groupValues[1], index + 1)) }
+                line.startsWith("import (") || line == "import(" -> inImportBlock = true
+                else -> singleImportRegex.find(line)?.let { imports.add(GoImport(it.groupValues[1], index + 1)) }
+            }
+        }
+
+        return imports
+    }
+}
@@ -0,0 +1,142 @@
+package com.forge.inference
+
+import com.forge.core.TargetConfiguration
+import java.nio.file.FileVisitResult
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.SimpleFileVisitor
+import java.nio.file.attribute.BasicFileAttributes
+import kotlin.io.path.exists
+import kotlin.io.path.name
+import kotlin.io.path.readText
+
+/**
+ * A Go package inside a module, identified by its directory relative to the module root ("." for the root package)
+ */
+data class GoPackage(
+    val dir: String,
+    val internalImports: Set<String> = emptySet(),
+    val hasTests: Boolean = false
+)
+
+/**
+ * Splits a Go module's test target into one cacheable target per package.
+ *
+ * Each package target only declares the package's own files and the non-test
+ * files of the internal packages it imports (transitively) as inputs, so a
+ * single-file edit only invalidates the packages that can observe it. The
+ * aggregate test target depends on every package target and reports their
+ * merged results as the project's test summary.
+ */
+object GoTestPackages {
+    private val SKIPPED_DIRECTORIES = setOf("vendor", "testdata", "node_modules")
+
+    /**
+     * Name of the per-package test target, e.g. `test/internal/handlers`
+     */
+    fun packageTargetName(testTargetName: String, dir: String): String = "$testTargetName/$dir"
+
+    /**
+     * Find every package of the module, with the packages of the same module it imports
+     */
+    fun scan(moduleDir: Path, modulePath: String): List<GoPackage> {
+        val goFilesByDir = mutableMapOf<String, MutableList<Path>>()
+
+        Files.walkFileTree(moduleDir, object : SimpleFileVisitor<Path>() {
+            override fun preVisitDirectory(dir: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (dir == moduleDir) return FileVisitResult.CONTINUE
+                val name = dir.name
+                return if (name in SKIPPED_DIRECTORIES || name.startsWith(".") || name.startsWith("_") ||
+                    dir.resolve("go.mod").exists()) {
+                    FileVisitResult.SKIP_SUBTREE
+                } else {
+                    FileVisitResult.CONTINUE
+                }
+            }
+
+            override fun visitFile(file: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (attrs.isRegularFile && file.name.endsWith(".go")) {
+                    val relativeDir = moduleDir.relativize(file.parent).toString().replace('\\', '/').ifEmpty { "." }
+                    goFilesByDir.getOrPut(relativeDir) { mutableListOf() }.add(file)
+                }
+                return FileVisitResult.CONTINUE
+            }
+        })
+
+        return goFilesByDir.toSortedMap().map { (dir, files) ->
+            val imports = files.flatMap { GoSource.imports(it.readText()) }
+                .mapNotNull { goImport -> internalDir(goImport.path, modulePath) }
+                .filter { it != dir && it in goFilesByDir }
+                .toSortedSet()
+            GoPackage(
+                dir = dir,
+                internalImports = imports,
+                hasTests = files.any { it.name.endsWith("_test.go") }
+            )
+        }
+    }
+
+    /**
+     * Build one test target per package that has tests, plus the aggregate target summarizing all of them
+     */
+    fun splitTestTargets(
+        projectRoot: String,
+        packages: List<GoPackage>,
+        testTargetName: String
+    ): Map<String, TargetConfiguration> {
+        val packagesByDir = packages.associateBy { it.dir }
+        val targets = linkedMapOf<String, TargetConfiguration>()
+
+        packages.filter { it.hasTests }.forEach { pkg ->
+            val imported = transitiveImports(pkg.dir, packagesByDir)
+            val inputs = mutableListOf(filesOf("{projectRoot}", pkg.dir, "*.go"))
+            imported.forEach { dir ->
+                inputs.add(filesOf("{projectRoot}", dir, "*.go"))
+                inputs.add("!" + filesOf("{projectRoot}", dir, "*_test.go"))
+            }
+            inputs.addAll(listOf("{projectRoot}/go.mod", "{projectRoot}/go.sum", "^default"))
+
+            targets[packageTargetName(testTargetName, pkg.dir)] = TargetConfiguration(
+                executor = "forge:run-commands",
+                options = mapOf(
+                    "commands" to listOf("go test ${if (pkg.dir == ".") "." else "./${pkg.dir}"}"),
+                    "cwd" to projectRoot
+                ),
+                inputs = inputs,
+                outputs = listOf(),
+                cache = true
+            )
+        }
+
+        // Merges the package results, cached or not, so it is never cached itself
+        targets[testTargetName] = TargetConfiguration(
+            executor = TargetConfiguration.TEST_SUMMARY_EXECUTOR,
+            inputs = listOf(),
+            outputs = listOf(),
+            cache = false,
+            dependsOn = targets.keys.toList()
+        )
+
+        return targets
+    }
+
+    private fun internalDir(importPath: String, modulePath: String): String? = when {
+        importPath == modulePath -> "."
+        importPath.startsWith("$modulePath/") -> importPath.removePrefix("$modulePath/")
+        else -> null
+    }
+
+    private fun transitiveImports(dir: String, packagesByDir: Map<String, GoPackage>): Set<String> {
+        val visited = sortedSetOf<String>()
+        val queue = ArrayDeque(packagesByDir[dir]?.internalImports ?: emptySet())
+        while (queue.isNotEmpty()) {
+            val next = queue.removeFirst()
+            if (next == dir || !visited.add(next)) continue
+            packagesByDir[next]?.internalImports?.let { queue.addAll(it) }
+        }
+        return visited
+    }
+
+    private fun filesOf(root: String, dir: String, pattern: String): String =
+        if (dir == ".") "$root/$pattern" else "$root/$dir/$pattern"
+}
@@ -1,5 +1,6 @@
 package com.forge.inference
 
+import com.forge.config.PluginConfiguration
 import com.forge.plugin.PluginManager
 import com.forge.plugin.ForgePlugin
 import org.slf4j.LoggerFactory
@@ -10,10 +11,12 @@ import kotlin.io.path.isRegularFile
 import kotlin.io.path.pathString
 
 /**
- * Engine for running ForgePlugins to discover project configurations
+ * Engine for running ForgePlugins to discover project configurations.
+ * When plugins are given they are used instead of the ones configured in the workspace.
  */
 class InferenceEngine(
-    private val pluginManager: PluginManager = PluginManager()
+    private val pluginManager: PluginManager = PluginManager(),
+    private val plugins: List<ForgePlugin>? = null
 ) {
     private val logger = LoggerFactory.getLogger(InferenceEngine::class.java)
     
@@ -31,15 +34,10 @@ class InferenceEngine(
         
         val allProjects = mutableMapOf<String, com.forge.core.ProjectConfiguration>()
         val allExternalNodes = mutableMapOf<String, Any>()
-        val allDependencies = mutableListOf<RawProjectGraphDependency>()
+        val allErrors = mutableListOf<InferenceError>()
         
         // Load ForgePlugins from workspace configuration
-        val forgePlugins = try {
-            pluginManager.loadPlugins(workspaceRoot)
-        } catch (e: Exception) {
-            logger.warn("Failed to load ForgePlugins, using built-in plugins: ${e.message}")
-            getBuiltInPlugins()
-        }
+        val forgePlugins = loadPlugins(workspaceRoot)
         
         forgePlugins.forEach { plugin ->
             try {
@@ -49,37 +47,16 @@ class InferenceEngine(
                 if (matchingFiles.isNotEmpty()) {
                     logger.debug("Found ${matchingFiles.size} files matching pattern '${plugin.metadata.createNodesPattern}'")
                     
-                    // Find plugin configuration options from workspace
-                    val options = plugin.defaultOptions
-                    
-                    val result = plugin.createNodes(
-                        matchingFiles,
-                        options,
-                        context
-                    )
-                    
-                    // Merge results - properly merge projects with same names
-                    result.projects.forEach { (projectName, projectConfig) ->
-                        if (allProjects.containsKey(projectName)) {
-                            // Merge with existing project
-                            val existing = allProjects[projectName]!!
-                            val mergedTargets = existing.targets + projectConfig.targets
-                            val mergedTags = (existing.tags + projectConfig.tags).distinct()
-                            allProjects[projectName] = existing.copy(
-                                targets = mergedTargets,
-                                tags = mergedTags
-                            )
-                            logger.debug("Merged plugin results for project '$projectName'")
-                        } else {
-                            allProjects[projectName] = projectConfig
-                        }
-                    }
+                    val result = createNodes(plugin, matchingFiles, context)
+                    allErrors.addAll(result.errors)
+                    mergeProjects(allProjects, result.projects)
                     allExternalNodes.putAll(result.externalNodes)
                     
                     logger.info("Plugin '${plugin.metadata.id}' inferred ${result.projects.size} projects")
                 }
             } catch (e: Exception) {
                 logger.error("Error running inference plugin '${plugin.metadata.id}': ${e.message}", e)
+                allErrors.add(InferenceError(plugin.metadata.id, null, e.message ?: e::class.simpleName ?: "Unknown error"))
             }
         }
         
@@ -89,13 +66,80 @@ class InferenceEngine(
             projects = allProjects,
             nxJsonConfiguration = nxJsonConfiguration
         )
+        val allDependencies = createDependencies(forgePlugins, dependenciesContext)
+        
+        if (allErrors.isNotEmpty()) {
+            logger.warn("Skipped ${allErrors.size} configuration file(s) that could not be inferred")
+        }
+        
+        return InferenceResult(
+            projects = allProjects,
+            dependencies = allDependencies,
+            externalNodes = allExternalNodes,
+            errors = allErrors
+        )
+    }
+    
+    /**
+     * The ForgePlugins used for the workspace, in the order they run
+     */
+    fun loadPlugins(workspaceRoot: Path): List<ForgePlugin> {
+        return plugins ?: try {
+            pluginManager.loadPlugins(workspaceRoot)
+        } catch (e: Exception) {
+            logger.warn("Failed to load ForgePlugins, using built-in plugins: ${e.message}")
+            getBuiltInPlugins()
+        }
+    }
+    
+    /**
+     * Whether a workspace-relative file is a configuration file the plugin creates nodes from
+     */
+    fun isConfigFile(workspaceRoot: Path, plugin: ForgePlugin, relativePath: String): Boolean {
+        val matcher = workspaceRoot.fileSystem.getPathMatcher("glob:${plugin.metadata.createNodesPattern}")
+        return matcher.matches(Path.of(relativePath))
+    }
+    
+    /**
+     * Every configuration file of the workspace the plugin creates nodes from, as absolute paths
+     */
+    fun findConfigFiles(workspaceRoot: Path, plugin: ForgePlugin): List<String> =
+        findMatchingFiles(workspaceRoot, plugin.metadata.createNodesPattern)
+    
+    /**
+     * Create nodes for the given configuration files with the plugin's options from the workspace configuration
+     */
+    fun createNodes(plugin: ForgePlugin, configFiles: List<String>, context: CreateNodesContext): CreateNodesResult {
+        val options = pluginOptions(plugin, context.nxJsonConfiguration)
+        return createNodesIsolated(plugin, configFiles, options, context)
+    }
+    
+    /**
+     * The options set for the plugin in the workspace's plugins list, e.g.
+     * `{ "plugin": "@forge/go", "options": { "splitTestsByPackage": true } }`, or its default options
+     */
+    internal fun pluginOptions(plugin: ForgePlugin, workspaceConfiguration: Map<String, Any>): Any? {
+        val configured = (workspaceConfiguration["plugins"] as? List<*>)
+            ?.filterIsInstance<PluginConfiguration>()
+            ?.firstOrNull { pluginId(it.plugin) == plugin.metadata.id }
+        return configured?.options?.ifEmpty { null } ?: plugin.defaultOptions
+    }
+    
+    /**
+     * Run dependency inference for every plugin, skipping plugins that fail
+     */
+    fun createDependencies(
+        forgePlugins: List<ForgePlugin>,
+        context: CreateDependenciesContext
+    ): List<RawProjectGraphDependency> {
+        val allDependencies = mutableListOf<RawProjectGraphDependency>()
         
         forgePlugins.forEach { plugin ->
             try {
                 logger.debug("Running dependency inference for plugin: ${plugin.metadata.id}")
-                val options = plugin.defaultOptions
+                val options = pluginOptions(plugin, context.nxJsonConfiguration)
                 
-                val dependencies = plugin.createDependencies(options, dependenciesContext)
+                val dependencies = plugin.createDependencies(options, context)
                 
                 allDependencies.addAll(dependencies)
                 logger.info("Plugin '${plugin.metadata.id}' inferred ${dependencies.size} dependencies")
@@ -104,11 +148,81 @@ class InferenceEngine(
             }
         }
         
-        return InferenceResult(
-            projects = allProjects,
-            dependencies = allDependencies,
-            externalNodes = allExternalNodes
-        )
+        return allDependencies
+    }
+    
+    /**
+     * Merge plugin results into the projects inferred so far, combining targets and tags of projects with the same name
+     */
+    fun mergeProjects(
+        allProjects: MutableMap<String, com.forge.core.ProjectConfiguration>,
+        projects: Map<String, com.forge.core.ProjectConfiguration>
+    ) {
+        projects.forEach { (projectName, projectConfig) ->
+            if (allProjects.containsKey(projectName)) {
+                // Merge with existing project
+                val existing = allProjects[projectName]!!
+                val mergedTargets = existing.targets + projectConfig.targets
+                val mergedTags = (existing.tags + projectConfig.tags).distinct()
+                allProjects[projectName] = existing.copy(
+                    targets = mergedTargets,
+                    tags = mergedTags
+                )
+                logger.debug("Merged plugin results for project '$projectName'")
+            } else {
+                allProjects[projectName] = projectConfig
+            }
+        }
+    }
+    
+    /**
+     * Run a plugin over all matching files at once, falling back to one file at a time if it fails
+     * so a single broken file only loses its own project
+     */
+    private fun createNodesIsolated(
+        plugin: ForgePlugin,
+        matchingFiles: List<String>,
+        options: Any?,
+        context: CreateNodesContext
+    ): CreateNodesResult {
+        return try {
+            plugin.createNodes(matchingFiles, options, context)
+        } catch (e: Exception) {
+            logger.warn("Plugin '${plugin.metadata.id}' failed on ${matchingFiles.size} file(s), retrying each file: ${e.message}")
+            
+            val results = matchingFiles.map { file ->
+                try {
+                    plugin.createNodes(listOf(file), options, context)
+                } catch (fileError: Exception) {
+                    logger.error("Plugin '${plugin.metadata.id}' failed on $file: ${fileError.message}")
+                    CreateNodesResult(errors = listOf(InferenceError(
+                        source = plugin.metadata.id,
+                        file = relativeToWorkspace(context, file),
+                        message = fileError.message ?: fileError::class.simpleName ?: "Unknown error"
+                    )))
+                }
+            }
+            
+            CreateNodesResult(
+                projects = results.fold(emptyMap()) { projects, result -> projects + result.projects },
+                externalNodes = results.fold(emptyMap()) { nodes, result -> nodes + result.externalNodes },
+                errors = results.flatMap { it.errors }
+            )
+        }
+    }
+    
+    /**
+     * Plugin id of a workspace plugin entry, mapping `@forge/go` to `com.forge.go`
+     */
+    private fun pluginId(plugin: String): String =
+        if (plugin.startsWith("@forge/")) "com.forge." + plugin.removePrefix("@forge/") else plugin
+    
+    private fun relativeToWorkspace(context: CreateNodesContext, file: String): String {
+        return try {
+            context.workspaceRoot.relativize(Path.of(file)).toString().replace('\\', '/')
+        } catch (e: IllegalArgumentException) {
+            file
+        }
     }
     
     /**
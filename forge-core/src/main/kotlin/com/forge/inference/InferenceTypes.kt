@@ -16,17 +16,36 @@ data class CreateNodesContext(
  */
 data class CreateNodesResult(
     val projects: Map<String, com.forge.core.ProjectConfiguration> = emptyMap(),
-    val externalNodes: Map<String, Any> = emptyMap()
+    val externalNodes: Map<String, Any> = emptyMap(),
+    val errors: List<InferenceError> = emptyList()
 )
 
 /**
- * Context provided to plugins during dependency creation
+ * A configuration file that could not be turned into a project
+ */
+data class InferenceError(
+    val source: String,
+    val file: String?,
+    val message: String
+)
+
+/**
+ * Context provided to plugins during dependency creation.
+ * When projectsToProcess is set only dependencies originating from those projects are needed;
+ * plugins may still return others, which are ignored.
  */
 data class CreateDependenciesContext(
     val workspaceRoot: Path,
     val projects: Map<String, com.forge.core.ProjectConfiguration>,
-    val nxJsonConfiguration: Map<String, Any> = emptyMap()
-)
+    val nxJsonConfiguration: Map<String, Any> = emptyMap(),
+    val projectsToProcess: Set<String>? = null
+) {
+    /**
+     * The projects whose outgoing dependencies should be computed
+     */
+    fun sourceProjects(): Collection<com.forge.core.ProjectConfiguration> =
+        projectsToProcess?.let { names -> projects.filterKeys { it in names }.values } ?: projects.values
+}
 
 /**
  * Represents a raw dependency between projects in the workspace
@@ -45,5 +64,6 @@ data class RawProjectGraphDependency(
 data class InferenceResult(
     val projects: Map<String, com.forge.core.ProjectConfiguration>,
     val dependencies: List<RawProjectGraphDependency>,
-    val externalNodes: Map<String, Any> = emptyMap()
+    val externalNodes: Map<String, Any> = emptyMap(),
+    val errors: List<InferenceError> = emptyList()
 )
\ No newline at end of file
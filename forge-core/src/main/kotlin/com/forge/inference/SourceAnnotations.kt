@@ -0,0 +1,132 @@
+package com.forge.inference
+
+import com.forge.core.ProjectConfiguration
+import com.forge.core.TargetConfiguration
+import org.slf4j.LoggerFactory
+import java.nio.file.FileVisitResult
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.SimpleFileVisitor
+import java.nio.file.attribute.BasicFileAttributes
+import kotlin.io.path.exists
+import kotlin.io.path.extension
+import kotlin.io.path.readText
+
+/**
+ * Project metadata declared with magic comments next to the code, e.g.
+ *
+ *     //forge:tags type:service,team:payments
+ *     //forge:target smoke go run ./cmd/smoke
+ */
+data class ProjectAnnotations(
+    val tags: List<String> = emptyList(),
+    val targets: Map<String, String> = emptyMap()
+) {
+    fun isEmpty(): Boolean = tags.isEmpty() && targets.isEmpty()
+
+    /**
+     * Fold the annotations into an inferred project.
+     *
+     * Annotated tags are appended to the inferred tags and annotated targets replace
+     * inferred targets of the same name. Discovery then merges any project.json for the
+     * same project on top, so a hand-written target always wins over an annotated one.
+     * Workspace targetDefaults from forge.json are applied last and only fill in fields
+     * the target leaves unset.
+     */
+    fun applyTo(project: ProjectConfiguration): ProjectConfiguration {
+        if (isEmpty()) return project
+
+        val annotatedTargets = targets.mapValues { (_, command) ->
+            TargetConfiguration(
+                executor = "forge:run-commands",
+                options = mapOf(
+                    "commands" to listOf(command),
+                    "cwd" to project.root
+                ),
+                // Ad-hoc targets declare no inputs or outputs, so there is nothing safe to cache
+                cache = false
+            )
+        }
+
+        return project.copy(
+            tags = (project.tags + tags).distinct(),
+            targets = project.targets + annotatedTargets
+        )
+    }
+}
+
+/**
+ * Parses `//forge:` annotations from source files
+ */
+object SourceAnnotations {
+    private val logger = LoggerFactory.getLogger(SourceAnnotations::class.java)
+    private val annotationRegex = Regex("""^\s*//forge:(\w+)\s+(.+)$""")
+    private val ignoredDirectories = setOf("vendor", "testdata", "node_modules", ".git")
+
+    /**
+     * Parse annotations from the contents of a single source file
+     */
+    fun parse(content: String): ProjectAnnotations {
+        val tags = mutableListOf<String>()
+        val targets = mutableMapOf<String, String>()
+
+        content.lines().forEach { line ->
+            val match = annotationRegex.find(line) ?: return@forEach
+            val (kind, value) = match.destructured
+
+            when (kind) {
+                "tags" -> tags.addAll(value.split(",").map { it.trim() }.filter { it.isNotEmpty() })
+                "target" -> {
+                    val parts = value.trim().split(Regex("""\s+"""), limit = 2)
+                    if (parts.size == 2) {
+                        targets[parts[0]] = parts[1].trim()
+                    } else {
+                        logger.warn("Ignoring //forge:target annotation without a command: $line")
+                    }
+                }
+                else -> logger.warn("Unknown forge annotation '$kind': $line")
+            }
+        }
+
+        return ProjectAnnotations(tags.distinct(), targets)
+    }
+
+    /**
+     * Collect annotations from all source files with the given extension under a project directory.
+     * Subdirectories containing their own moduleFile (e.g. go.mod) are separate projects and are skipped.
+     */
+    fun scan(projectDir: Path, extension: String, moduleFile: String? = null): ProjectAnnotations {
+        if (!projectDir.exists()) return ProjectAnnotations()
+
+        val tags = mutableListOf<String>()
+        val targets = mutableMapOf<String, String>()
+
+        Files.walkFileTree(projectDir, object : SimpleFileVisitor<Path>() {
+            override fun preVisitDirectory(dir: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (dir == projectDir) return FileVisitResult.CONTINUE
+
+                return if (dir.fileName.toString() in ignoredDirectories ||
+                    (moduleFile != null && dir.resolve(moduleFile).exists())) {
+                    FileVisitResult.SKIP_SUBTREE
+                } else {
+                    FileVisitResult.CONTINUE
+                }
+            }
+
+            override fun visitFile(file: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (file.extension == extension) {
+                    try {
+                        val annotations = parse(file.readText())
+                        tags.addAll(annotations.tags)
+                        targets.putAll(annotations.targets)
+                    } catch (e: Exception) {
+                        logger.warn("Failed to read annotations from $file: ${e.message}")
+                    }
+                }
+                return FileVisitResult.CONTINUE
+            }
+        })
+
+        return ProjectAnnotations(tags.distinct(), targets)
+    }
+}
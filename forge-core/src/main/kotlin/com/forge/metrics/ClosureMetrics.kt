@@ -0,0 +1,56 @@
+package com.forge.metrics
+
+import com.forge.core.ProjectGraph
+
+/**
+ * Size of a project's transitive dependency and dependent closures
+ */
+data class ProjectClosure(
+    val project: String,
+    val dependencies: Set<String>,
+    val dependents: Set<String>
+) {
+    val dependencyCount: Int get() = dependencies.size
+    val dependentCount: Int get() = dependents.size
+}
+
+/**
+ * Ordering of a closure report
+ */
+enum class ClosureSort {
+    DEPENDENCIES,
+    DEPENDENTS,
+    NAME
+}
+
+/**
+ * Computes transitive closure sizes for every project.
+ *
+ * A project with a large dependency closure is rebuilt whenever any of them
+ * changes; one with a large dependent closure forces rebuilds across the
+ * workspace. Projects high on either list are coupling hotspots.
+ */
+class ClosureMetrics(
+    private val projectGraph: ProjectGraph
+) {
+    /**
+     * Closure of every project, largest first for the count being sorted on
+     */
+    fun compute(sort: ClosureSort = ClosureSort.DEPENDENCIES): List<ProjectClosure> {
+        val closures = projectGraph.nodes.keys.map { project ->
+            ProjectClosure(
+                project = project,
+                dependencies = projectGraph.getTransitiveDependencies(project) - project,
+                dependents = projectGraph.getTransitiveDependents(project) - project
+            )
+        }
+
+        return when (sort) {
+            ClosureSort.DEPENDENCIES -> closures.sortedWith(
+                compareByDescending<ProjectClosure> { it.dependencyCount }.thenBy { it.project })
+            ClosureSort.DEPENDENTS -> closures.sortedWith(
+                compareByDescending<ProjectClosure> { it.dependentCount }.thenBy { it.project })
+            ClosureSort.NAME -> closures.sortedBy { it.project }
+        }
+    }
+}
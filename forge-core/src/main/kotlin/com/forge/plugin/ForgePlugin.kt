@@ -37,7 +37,9 @@ interface ForgePlugin {
         get() = null
     
     /**
-     * Create project nodes from configuration files
+     * Create project nodes from configuration files.
+     * Throw when a file cannot be read rather than skipping it: the engine then retries file by file
+     * and reports the broken file as an [com.forge.inference.InferenceError], keeping the other projects.
      */
     fun createNodes(
         configFiles: List<String>, 
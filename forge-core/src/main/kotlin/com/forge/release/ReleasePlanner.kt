@@ -0,0 +1,95 @@
+package com.forge.release
+
+import com.forge.core.ProjectGraph
+import java.nio.file.Path
+
+/**
+ * A project scheduled for release, in build/publish order
+ */
+data class ReleasePlanEntry(
+    val project: String,
+    val root: String,
+    val version: String?,
+    val dependsOn: List<String>,
+    val warnings: List<String> = emptyList()
+)
+
+/**
+ * Ordered list of projects to build and publish for a release
+ */
+data class ReleasePlan(
+    val target: String,
+    val entries: List<ReleasePlanEntry>
+) {
+    fun projectNames(): List<String> = entries.map { it.project }
+
+    fun isEmpty(): Boolean = entries.isEmpty()
+}
+
+/**
+ * Computes the order in which releasable projects must be built and published
+ */
+class ReleasePlanner(
+    workspaceRoot: Path,
+    private val projectGraph: ProjectGraph,
+    private val versionResolver: VersionResolver = VersionResolver(workspaceRoot)
+) {
+    companion object {
+        const val DEFAULT_RELEASE_TAG = "releasable"
+    }
+
+    /**
+     * Plan a release of every project carrying the release tag and the given target.
+     * Dependencies always come before their dependents; disagreeing version sources
+     * are reported as warnings on the entry.
+     */
+    fun plan(targetName: String, releaseTag: String = DEFAULT_RELEASE_TAG): ReleasePlan {
+        val releasable = projectGraph.getAllProjects()
+            .filter { it.data.hasTag(releaseTag) && it.data.hasTarget(targetName) }
+            .map { it.name }
+            .toSet()
+
+        val entries = topologicalOrder()
+            .filter { it in releasable }
+            .map { projectName ->
+                val project = projectGraph.getProject(projectName)!!.data
+                val resolved = versionResolver.resolve(project)
+                ReleasePlanEntry(
+                    project = projectName,
+                    root = project.root,
+                    version = resolved.version,
+                    dependsOn = projectGraph.getTransitiveDependencies(projectName)
+                        .filter { it in releasable }
+                        .sorted(),
+                    warnings = resolved.warnings
+                )
+            }
+
+        return ReleasePlan(targetName, entries)
+    }
+
+    /**
+     * Kahn's algorithm over the project graph, breaking ties alphabetically for a stable order
+     */
+    private fun topologicalOrder(): List<String> {
+        val remaining = projectGraph.nodes.keys.associateWith { project ->
+            projectGraph.getDependencies(project).map { it.target }.filter { projectGraph.hasProject(it) }.toMutableSet()
+        }.toMutableMap()
+        val order = mutableListOf<String>()
+
+        while (remaining.isNotEmpty()) {
+            val ready = remaining.filterValues { it.isEmpty() }.keys.sorted()
+            if (ready.isEmpty()) {
+                throw IllegalStateException("Circular project dependency detected between: ${remaining.keys.sorted().joinToString(", ")}")
+            }
+
+            ready.forEach { project ->
+                order.add(project)
+                remaining.remove(project)
+                remaining.values.forEach { it.remove(project) }
+            }
+        }
+
+        return order
+    }
+}
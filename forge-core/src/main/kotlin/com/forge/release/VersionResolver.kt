@@ -0,0 +1,143 @@
+package com.forge.release
+
+import com.forge.core.ProjectConfiguration
+import org.slf4j.LoggerFactory
+import java.nio.file.Path
+import java.util.concurrent.TimeUnit
+import kotlin.io.path.exists
+import kotlin.io.path.readText
+
+/**
+ * Where a project version was read from, in order of precedence
+ */
+enum class VersionSource(val description: String) {
+    CONFIG("project configuration"),
+    VERSION_FILE("VERSION file"),
+    GIT_TAG("git tag"),
+    MODULE_PATH("module path")
+}
+
+/**
+ * Version chosen for a project along with every candidate that was found
+ */
+data class ResolvedVersion(
+    val version: String?,
+    val source: VersionSource?,
+    val candidates: Map<VersionSource, String> = emptyMap(),
+    val warnings: List<String> = emptyList()
+)
+
+/**
+ * Infers a version for each project for consistent monorepo tagging.
+ *
+ * Sources are consulted in [VersionSource] order and the first one found wins:
+ * an explicit `version` in the project configuration, a `VERSION` file in the
+ * project root, the highest git tag named `<project>/vX.Y.Z`, and finally the
+ * `/vN` major version suffix of the go.mod module path. When sources disagree a
+ * warning is recorded; the module path only has to agree on the major version.
+ *
+ * Versions are resolved on demand rather than during project discovery, since
+ * listing git tags on every discovery is slow.
+ */
+class VersionResolver(
+    private val workspaceRoot: Path,
+    private val tagProvider: () -> List<String> = { listGitTags(workspaceRoot) }
+) {
+    private val tags: List<String> by lazy { tagProvider() }
+
+    companion object {
+        private val logger = LoggerFactory.getLogger(VersionResolver::class.java)
+        private val semverRegex = Regex("""^v?(\d+)\.(\d+)\.(\d+)([-+].*)?$""")
+        private val majorSuffixRegex = Regex("""/v(\d+)$""")
+
+        /**
+         * List the tags of the git repository containing the workspace, or nothing outside git
+         */
+        fun listGitTags(workspaceRoot: Path): List<String> {
+            return try {
+                val process = ProcessBuilder("git", "tag", "--list")
+                    .directory(workspaceRoot.toFile())
+                    .redirectErrorStream(true)
+                    .start()
+                val output = process.inputStream.bufferedReader().readLines()
+                if (!process.waitFor(30, TimeUnit.SECONDS) || process.exitValue() != 0) {
+                    emptyList()
+                } else {
+                    output.map { it.trim() }.filter { it.isNotEmpty() }
+                }
+            } catch (e: Exception) {
+                logger.debug("Unable to list git tags: ${e.message}")
+                emptyList()
+            }
+        }
+    }
+
+    fun resolve(project: ProjectConfiguration): ResolvedVersion {
+        val candidates = linkedMapOf<VersionSource, String>()
+        val projectDir = workspaceRoot.resolve(project.root)
+
+        project.version?.takeIf { it.isNotBlank() }?.let {
+            candidates[VersionSource.CONFIG] = it.trim().removePrefix("v")
+        }
+        readVersionFile(projectDir)?.let { candidates[VersionSource.VERSION_FILE] = it }
+        latestTagVersion(project.name)?.let { candidates[VersionSource.GIT_TAG] = it }
+        moduleMajorVersion(projectDir)?.let { candidates[VersionSource.MODULE_PATH] = it }
+
+        val chosen = candidates.entries.firstOrNull() ?: return ResolvedVersion(null, null)
+
+        val conflicts = candidates.filter { (source, version) ->
+            source != chosen.key && !agrees(chosen.value, source, version)
+        }
+        val warnings = if (conflicts.isNotEmpty()) {
+            val details = candidates.entries.joinToString(", ") { (source, version) -> "${source.description}=$version" }
+            listOf("Project '${project.name}' version sources disagree ($details); using ${chosen.value} from ${chosen.key.description}")
+        } else {
+            emptyList()
+        }
+        warnings.forEach { logger.warn(it) }
+
+        return ResolvedVersion(chosen.value, chosen.key, candidates, warnings)
+    }
+
+    private fun agrees(chosen: String, source: VersionSource, version: String): Boolean {
+        return if (source == VersionSource.MODULE_PATH) {
+            chosen.substringBefore(".") == version.substringBefore(".")
+        } else {
+            chosen == version
+        }
+    }
+
+    private fun readVersionFile(projectDir: Path): String? {
+        val versionFile = projectDir.resolve("VERSION")
+        if (!versionFile.exists()) return null
+        return versionFile.readText().trim().removePrefix("v").ifEmpty { null }
+    }
+
+    private fun latestTagVersion(projectName: String): String? {
+        val prefix = "$projectName/"
+        return tags.asSequence()
+            .filter { it.startsWith(prefix) }
+            .mapNotNull { tag -> semverRegex.matchEntire(tag.removePrefix(prefix)) }
+            .maxWithOrNull(compareBy<MatchResult>(
+                { it.groupValues[1].toInt() },
+                { it.groupValues[2].toInt() },
+                { it.groupValues[3].toInt() }
+            ))
+            ?.value
+            ?.removePrefix("v")
+    }
+
+    private fun moduleMajorVersion(projectDir: Path): String? {
+        val goMod = projectDir.resolve("go.mod")
+        if (!goMod.exists()) return null
+
+        val modulePath = goMod.readText().lines()
+            .map { it.trim() }
+            .firstOrNull { it.startsWith("module ") }
+            ?.removePrefix("module ")
+            ?.trim()
+            ?: return null
+        val major = majorSuffixRegex.find(modulePath)?.groupValues?.get(1) ?: return null
+        return "$major.0.0"
+    }
+}
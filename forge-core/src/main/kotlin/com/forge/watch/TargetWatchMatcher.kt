@@ -0,0 +1,32 @@
+package com.forge.watch
+
+import com.forge.cache.TaskInputResolver
+import com.forge.core.ProjectGraph
+
+/**
+ * Maps changed workspace files to the targets whose declared inputs match them
+ */
+class TargetWatchMatcher(
+    private val projectGraph: ProjectGraph,
+    private val inputResolver: TaskInputResolver,
+    private val targetNames: Set<String>,
+    private val projectNames: Set<String> = projectGraph.nodes.keys
+) {
+    /**
+     * Group the projects to re-run by target name for a batch of changed workspace-relative paths
+     */
+    fun matchByTarget(changedFiles: Collection<String>): Map<String, List<String>> {
+        val matched = mutableMapOf<String, MutableList<String>>()
+
+        targetNames.sorted().forEach { targetName ->
+            projectNames.sorted().forEach { projectName ->
+                val target = projectGraph.getProject(projectName)?.data?.getTarget(targetName)
+                if (target != null && changedFiles.any { inputResolver.matches(projectName, target, it) }) {
+                    matched.getOrPut(targetName) { mutableListOf() }.add(projectName)
+                }
+            }
+        }
+
+        return matched
+    }
+}
@@ -0,0 +1,105 @@
+package com.forge.watch
+
+import org.slf4j.LoggerFactory
+import java.nio.file.ClosedWatchServiceException
+import java.nio.file.FileSystems
+import java.nio.file.FileVisitResult
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.SimpleFileVisitor
+import java.nio.file.StandardWatchEventKinds
+import java.nio.file.WatchKey
+import java.nio.file.attribute.BasicFileAttributes
+import java.util.concurrent.TimeUnit
+import kotlin.io.path.isDirectory
+
+/**
+ * Watches a workspace recursively and reports batches of changed files
+ */
+class WorkspaceWatcher(
+    private val workspaceRoot: Path,
+    private val debounceMillis: Long = 200
+) : AutoCloseable {
+    private val logger = LoggerFactory.getLogger(WorkspaceWatcher::class.java)
+    private val watchService = FileSystems.getDefault().newWatchService()
+    private val watchedDirectories = mutableMapOf<WatchKey, Path>()
+
+    companion object {
+        private val IGNORED_DIRECTORIES = setOf(".git", ".forge", "node_modules", "target", "dist")
+    }
+
+    /**
+     * Block and invoke the callback with workspace-relative paths each time files change.
+     * Returns once the watcher is closed.
+     */
+    fun watch(onChange: (List<String>) -> Unit) {
+        registerRecursively(workspaceRoot)
+        logger.info("Watching ${watchedDirectories.size} directories in $workspaceRoot")
+
+        try {
+            while (true) {
+                val changed = sortedSetOf<String>()
+                val first = watchService.take()
+                collectEvents(first, changed)
+
+                // Debounce bursts of events (editors often write several times per save)
+                var next = watchService.poll(debounceMillis, TimeUnit.MILLISECONDS)
+                while (next != null) {
+                    collectEvents(next, changed)
+                    next = watchService.poll(debounceMillis, TimeUnit.MILLISECONDS)
+                }
+
+                if (changed.isNotEmpty()) {
+                    onChange(changed.toList())
+                }
+            }
+        } catch (e: ClosedWatchServiceException) {
+            logger.debug("Workspace watcher closed")
+        } catch (e: InterruptedException) {
+            Thread.currentThread().interrupt()
+        }
+    }
+
+    override fun close() {
+        watchService.close()
+    }
+
+    private fun collectEvents(key: WatchKey, changed: MutableSet<String>) {
+        val directory = watchedDirectories[key]
+        key.pollEvents().forEach { event ->
+            val context = event.context() as? Path ?: return@forEach
+            if (directory == null) return@forEach
+
+            val path = directory.resolve(context)
+            if (event.kind() == StandardWatchEventKinds.ENTRY_CREATE && path.isDirectory()) {
+                registerRecursively(path)
+            } else if (!isIgnored(path)) {
+                changed.add(workspaceRoot.relativize(path).toString().replace('\\', '/'))
+            }
+        }
+        if (!key.reset()) {
+            watchedDirectories.remove(key)
+        }
+    }
+
+    private fun registerRecursively(root: Path) {
+        Files.walkFileTree(root, object : SimpleFileVisitor<Path>() {
+            override fun preVisitDirectory(dir: Path, attrs: BasicFileAttributes): FileVisitResult {
+                if (isIgnored(dir)) return FileVisitResult.SKIP_SUBTREE
+
+                val key = dir.register(
+                    watchService,
+                    StandardWatchEventKinds.ENTRY_CREATE,
+                    StandardWatchEventKinds.ENTRY_MODIFY,
+                    StandardWatchEventKinds.ENTRY_DELETE
+                )
+                watchedDirectories[key] = dir
+                return FileVisitResult.CONTINUE
+            }
+        })
+    }
+
+    private fun isIgnored(path: Path): Boolean {
+        return workspaceRoot.relativize(path).any { it.toString() in IGNORED_DIRECTORIES }
+    }
+}
@@ -0,0 +1,237 @@
+package com.forge.affected
+
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import com.forge.inference.SourceAnnotations
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Assumptions.assumeTrue
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.Paths
+import kotlin.io.path.writeText
+
+class AffectedProjectsCalculatorTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private lateinit var calculator: AffectedProjectsCalculator
+
+    @BeforeEach
+    fun setup() {
+        val projectGraph = ProjectGraph(
+            nodes = mapOf(
+                "go-utils" to project("go-utils", "libs/go-utils"),
+                "go-utils-testing" to project("go-utils-testing", "libs/go-utils/testing"),
+                "api-gateway" to project("api-gateway", "services/api-gateway"),
+                "user-service" to project("user-service", "services/user-service")
+            ),
+            dependencies = mapOf(
+                "api-gateway" to listOf(ProjectGraphDependency("api-gateway", "go-utils", DependencyType.STATIC)),
+                "go-utils" to emptyList(),
+                "go-utils-testing" to emptyList(),
+                "user-service" to emptyList()
+            )
+        )
+        calculator = AffectedProjectsCalculator(workspaceRoot, projectGraph)
+    }
+
+    @Test
+    fun `should include owning project and its dependents`() {
+        val affected = calculator.affectedByFiles(listOf("libs/go-utils/utils.go"))
+
+        assertEquals(setOf("go-utils"), affected.directlyAffected)
+        assertEquals(setOf("api-gateway", "go-utils"), affected.affected)
+    }
+
+    @Test
+    fun `should attribute files to the most specific project root`() {
+        assertEquals("go-utils-testing", calculator.ownerOf("libs/go-utils/testing/fake.go"))
+        assertEquals("go-utils", calculator.ownerOf("./libs/go-utils/utils.go"))
+        assertNull(calculator.ownerOf("docs/README.md"))
+    }
+
+    @Test
+    fun `should attribute unclaimed files to a workspace root project`() {
+        val projectGraph = ProjectGraph(
+            nodes = mapOf(
+                "workspace" to project("workspace", "."),
+                "go-utils" to project("go-utils", "libs/go-utils")
+            ),
+            dependencies = mapOf("workspace" to emptyList(), "go-utils" to emptyList())
+        )
+        val rootCalculator = AffectedProjectsCalculator(workspaceRoot, projectGraph)
+
+        assertEquals("workspace", rootCalculator.ownerOf("docs/README.md"))
+        assertEquals("workspace", rootCalculator.ownerOf("Makefile"))
+        assertEquals("go-utils", rootCalculator.ownerOf("libs/go-utils/utils.go"))
+        assertEquals(setOf("workspace"), rootCalculator.affectedByFiles(listOf("scripts/release.sh")).directlyAffected)
+    }
+
+    @Test
+    fun `should accept multiple changed files`() {
+        val affected = calculator.affectedByFiles(listOf(
+            "libs/go-utils/utils.go",
+            "services/user-service/main.go",
+            "docs/README.md"
+        ))
+
+        assertEquals(setOf("api-gateway", "go-utils", "user-service"), affected.affected)
+    }
+
+    @Test
+    fun `should affect every project when workspace configuration changes`() {
+        val affected = calculator.affectedByFiles(listOf("forge.json"))
+
+        assertEquals(4, affected.affected.size)
+    }
+
+    @Test
+    fun `simulated changes should match affected computed from git`() {
+        assumeTrue(gitAvailable(), "git is not installed")
+
+        writeFile("libs/go-utils/utils.go", "package utils\n")
+        writeFile("services/user-service/main.go", "package main\n")
+        git("init", "-q")
+        git("add", "-A")
+        git("-c", "user.name=forge", "-c", "user.email=forge@example.com", "commit", "-q", "-m", "initial")
+
+        writeFile("libs/go-utils/utils.go", "package utils\n\nfunc Noop() {}\n")
+        writeFile("libs/go-utils/strings.go", "package utils\n")
+
+        val real = calculator.affectedSince("HEAD")
+        val simulated = calculator.affectedByFiles(listOf("libs/go-utils/utils.go", "libs/go-utils/strings.go"))
+
+        assertEquals(listOf("libs/go-utils/strings.go", "libs/go-utils/utils.go"), real.changedFiles)
+        assertEquals(real, simulated)
+    }
+
+    @Test
+    fun `should mark targets whose inputs did not change as cache hits`() {
+        val calculator = AffectedProjectsCalculator(workspaceRoot, buildGraph())
+
+        val affected = calculator.affectedByFiles(listOf("services/api-gateway/README.md"))
+        val tasks = calculator.affectedTasks(affected, "build")
+        val docs = calculator.affectedTasks(affected, "docs")
+
+        assertEquals(setOf("api-gateway"), affected.affected)
+        assertEquals(listOf("api-gateway:build"), tasks.map { it.taskId })
+        assertTrue(tasks.single().wouldBeCached, "README.md is not a build input")
+        assertFalse(docs.single().wouldBeCached)
+        assertEquals(listOf("services/api-gateway/README.md"), docs.single().changedInputs)
+    }
+
+    @Test
+    fun `should not mark dependents as cache hits when a dependency input changed`() {
+        val calculator = AffectedProjectsCalculator(workspaceRoot, buildGraph())
+
+        val affected = calculator.affectedByFiles(listOf("libs/go-utils/utils.go"))
+        val tasks = calculator.affectedTasks(affected, "build").associateBy { it.taskId }
+
+        assertEquals(setOf("api-gateway:build", "go-utils:build"), tasks.keys)
+        assertEquals(listOf("libs/go-utils/utils.go"), tasks.getValue("api-gateway:build").changedInputs)
+        assertTrue(tasks.values.none { it.wouldBeCached })
+    }
+
+    @Test
+    fun `should keep only affected projects owned by the team`() {
+        val (teamWorkspace, projectGraph) = teamGraph()
+        val calculator = AffectedProjectsCalculator(teamWorkspace, projectGraph)
+
+        val affected = calculator.affectedByFiles(listOf("libs/go-utils/utils.go"))
+        val payments = calculator.ownedBy(affected, "payments")
+
+        assertEquals(setOf("api-gateway", "go-utils", "payments", "payments-worker"), affected.affected)
+        assertEquals(setOf("payments", "payments-worker"), payments.affected)
+        assertTrue(payments.directlyAffected.isEmpty(), "go-utils is owned by the platform team")
+        assertEquals(affected.changedFiles, payments.changedFiles)
+        assertEquals(setOf("go-utils"), calculator.ownedBy(affected, "platform").affected)
+    }
+
+    @Test
+    fun `should report no projects for a team owning none of the affected ones`() {
+        val (teamWorkspace, projectGraph) = teamGraph()
+        val calculator = AffectedProjectsCalculator(teamWorkspace, projectGraph)
+
+        val affected = calculator.affectedByFiles(listOf("services/api-gateway/main.go"))
+
+        assertEquals(setOf("api-gateway"), calculator.ownedBy(affected, "edge").affected)
+        assertTrue(calculator.ownedBy(affected, "payments").isEmpty())
+    }
+
+    /**
+     * Go projects of the team fixture, tagged from their //forge:tags owner annotations
+     */
+    private fun teamGraph(): Pair<Path, ProjectGraph> {
+        val resourcesPath = this::class.java.classLoader.getResource("test-team-workspace")?.toURI()
+        assertNotNull(resourcesPath, "Test workspace not found in resources")
+        val teamWorkspace = Paths.get(resourcesPath!!)
+
+        val roots = mapOf(
+            "go-utils" to "libs/go-utils",
+            "payments" to "services/payments",
+            "payments-worker" to "services/payments-worker",
+            "api-gateway" to "services/api-gateway"
+        )
+        val nodes = roots.mapValues { (name, root) ->
+            val project = SourceAnnotations.scan(teamWorkspace.resolve(root), "go")
+                .applyTo(ProjectConfiguration(name = name, root = root))
+            ProjectGraphNode(name, "library", project)
+        }
+        val dependencies = roots.keys.associateWith { name ->
+            if (name == "go-utils") emptyList() else listOf(ProjectGraphDependency(name, "go-utils", DependencyType.STATIC))
+        }
+        return teamWorkspace to ProjectGraph(nodes, dependencies)
+    }
+
+    private fun buildGraph(): ProjectGraph {
+        val targets = mapOf(
+            "build" to TargetConfiguration(inputs = listOf("{projectRoot}/**/*.go", "^default")),
+            "docs" to TargetConfiguration(inputs = listOf("{projectRoot}/*.md"))
+        )
+        return ProjectGraph(
+            nodes = mapOf(
+                "go-utils" to ProjectGraphNode("go-utils", "library",
+                    ProjectConfiguration(name = "go-utils", root = "libs/go-utils", targets = targets)),
+                "api-gateway" to ProjectGraphNode("api-gateway", "application",
+                    ProjectConfiguration(name = "api-gateway", root = "services/api-gateway", targets = targets))
+            ),
+            dependencies = mapOf(
+                "api-gateway" to listOf(ProjectGraphDependency("api-gateway", "go-utils", DependencyType.STATIC)),
+                "go-utils" to emptyList()
+            )
+        )
+    }
+
+    private fun project(name: String, root: String): ProjectGraphNode {
+        return ProjectGraphNode(name, "library", ProjectConfiguration(name = name, root = root))
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+
+    private fun gitAvailable(): Boolean = try {
+        ProcessBuilder("git", "--version").start().waitFor() == 0
+    } catch (e: Exception) {
+        false
+    }
+
+    private fun git(vararg args: String) {
+        val process = ProcessBuilder(listOf("git") + args)
+            .directory(workspaceRoot.toFile())
+            .redirectErrorStream(true)
+            .start()
+        process.inputStream.bufferedReader().readText()
+        assertEquals(0, process.waitFor(), "git ${args.joinToString(" ")} failed")
+    }
+}
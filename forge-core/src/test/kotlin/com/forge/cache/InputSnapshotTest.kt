@@ -0,0 +1,185 @@
+package com.forge.cache
+
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import com.forge.graph.Task
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.writeText
+
+class InputSnapshotTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private lateinit var resolver: TaskInputResolver
+    private lateinit var task: Task
+
+    @BeforeEach
+    fun setup() {
+        val projectDir = workspaceRoot.resolve("services/api-gateway")
+        Files.createDirectories(projectDir.resolve("internal"))
+        projectDir.resolve("go.mod").writeText("module github.com/example/api-gateway\n")
+        projectDir.resolve("main.go").writeText("package main\n")
+        projectDir.resolve("internal/router.go").writeText("package internal\n")
+        projectDir.resolve("README.md").writeText("# API Gateway\n")
+
+        val buildTarget = TargetConfiguration(
+            executor = "forge:run-commands",
+            options = mapOf("commands" to listOf("go build ./...")),
+            inputs = listOf("{projectRoot}/**/*.go", "{projectRoot}/go.mod")
+        )
+        val project = ProjectConfiguration(
+            name = "api-gateway",
+            root = "services/api-gateway",
+            targets = mapOf("build" to buildTarget)
+        )
+        val projectGraph = ProjectGraph(
+            nodes = mapOf("api-gateway" to ProjectGraphNode("api-gateway", "application", project)),
+            dependencies = mapOf("api-gateway" to emptyList())
+        )
+
+        resolver = TaskInputResolver(workspaceRoot, projectGraph)
+        task = Task(
+            id = "api-gateway:build",
+            projectName = "api-gateway",
+            targetName = "build",
+            target = buildTarget,
+            hash = "config-hash"
+        )
+    }
+
+    @Test
+    fun `should resolve only declared input files`() {
+        val files = resolver.resolveFiles("api-gateway", task.target)
+
+        assertEquals(
+            listOf(
+                "services/api-gateway/go.mod",
+                "services/api-gateway/internal/router.go",
+                "services/api-gateway/main.go"
+            ),
+            files
+        )
+    }
+
+    @Test
+    fun `should report only the changed input file`() {
+        val store = InputSnapshotStore(workspaceRoot)
+        store.save(resolver.snapshot(task))
+
+        workspaceRoot.resolve("services/api-gateway/internal/router.go")
+            .writeText("package internal\n\nfunc Route() {}\n")
+
+        val previous = store.load(task.id)
+        assertNotNull(previous, "Snapshot should be stored")
+
+        val current = resolver.snapshot(task)
+        val diff = InputSnapshotDiff.between(previous!!, current)
+
+        assertNotEquals(previous.key, current.key)
+        assertEquals(listOf("services/api-gateway/internal/router.go"), diff.changed)
+        assertTrue(diff.added.isEmpty(), "No files should be reported as added")
+        assertTrue(diff.removed.isEmpty(), "No files should be reported as removed")
+        assertFalse(diff.configChanged)
+    }
+
+    @Test
+    fun `should report added and removed input files`() {
+        val previous = resolver.snapshot(task)
+
+        Files.delete(workspaceRoot.resolve("services/api-gateway/main.go"))
+        workspaceRoot.resolve("services/api-gateway/internal/handler.go").writeText("package internal\n")
+
+        val diff = InputSnapshotDiff.between(previous, resolver.snapshot(task))
+
+        assertEquals(listOf("services/api-gateway/internal/handler.go"), diff.added)
+        assertEquals(listOf("services/api-gateway/main.go"), diff.removed)
+        assertTrue(diff.changed.isEmpty())
+    }
+
+    @Test
+    fun `should ignore changes to files outside the inputs`() {
+        val previous = resolver.snapshot(task)
+
+        workspaceRoot.resolve("services/api-gateway/README.md").writeText("# Changed\n")
+
+        val current = resolver.snapshot(task)
+        assertEquals(previous.key, current.key)
+        assertTrue(InputSnapshotDiff.between(previous, current).isEmpty)
+    }
+
+    @Test
+    fun `should exclude every file a negated named input selects`() {
+        val target = TargetConfiguration(inputs = listOf("default", "!docs"))
+        val resolver = TaskInputResolver(
+            workspaceRoot,
+            graphOf(ProjectConfiguration(name = "api-gateway", root = "services/api-gateway")),
+            namedInputs = mapOf("docs" to listOf("{projectRoot}/**/*.md"))
+        )
+        val docsTask = Task(
+            id = "api-gateway:lint",
+            projectName = "api-gateway",
+            targetName = "lint",
+            target = target,
+            hash = "config-hash"
+        )
+        val previous = resolver.snapshot(docsTask)
+
+        workspaceRoot.resolve("services/api-gateway/README.md").writeText("# Changed\n")
+
+        assertFalse("services/api-gateway/README.md" in resolver.resolveFiles("api-gateway", target))
+        assertTrue(InputSnapshotDiff.between(previous, resolver.snapshot(docsTask)).isEmpty,
+            "A change to an excluded file must not be reported as a cause")
+    }
+
+    @Test
+    fun `should exclude the files of a negated dependency input`() {
+        val utilsDir = workspaceRoot.resolve("libs/go-utils")
+        Files.createDirectories(utilsDir)
+        utilsDir.resolve("utils.go").writeText("package utils\n")
+        utilsDir.resolve("utils_test.go").writeText("package utils\n")
+
+        val target = TargetConfiguration(inputs = listOf("{projectRoot}/**/*.go", "^default", "!^tests"))
+        val resolver = TaskInputResolver(
+            workspaceRoot,
+            graphOf(
+                ProjectConfiguration(name = "api-gateway", root = "services/api-gateway"),
+                ProjectConfiguration(name = "go-utils", root = "libs/go-utils")
+            ),
+            namedInputs = mapOf("tests" to listOf("{projectRoot}/**/*_test.go"))
+        )
+
+        assertEquals(
+            listOf(
+                "libs/go-utils/utils.go",
+                "services/api-gateway/internal/router.go",
+                "services/api-gateway/main.go"
+            ),
+            resolver.resolveFiles("api-gateway", target)
+        )
+    }
+
+    private fun graphOf(vararg projects: ProjectConfiguration): ProjectGraph {
+        val names = projects.map { it.name }
+        return ProjectGraph(
+            nodes = projects.associate { it.name to ProjectGraphNode(it.name, "library", it) },
+            // The first project depends on every other one
+            dependencies = names.associateWith { name ->
+                if (name == names.first()) {
+                    names.drop(1).map { ProjectGraphDependency(name, it, DependencyType.STATIC) }
+                } else {
+                    emptyList()
+                }
+            }
+        )
+    }
+}
@@ -0,0 +1,78 @@
+package com.forge.check
+
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.fasterxml.jackson.module.kotlin.readValue
+import com.forge.config.WorkspaceConfiguration
+import com.forge.discovery.ProjectDiscovery
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import java.nio.file.Path
+import java.nio.file.Paths
+
+class ConfigLinterTest {
+
+    private lateinit var workspaceRoot: Path
+    private lateinit var issues: List<ConfigIssue>
+
+    @BeforeEach
+    fun setup() {
+        val resourcesPath = this::class.java.classLoader.getResource("test-lint-workspace")?.toURI()
+        assertNotNull(resourcesPath, "Test workspace not found in resources")
+        workspaceRoot = Paths.get(resourcesPath!!)
+
+        val projectGraph = ProjectDiscovery(workspaceRoot).discoverProjects()
+        val namedInputs = jacksonObjectMapper()
+            .readValue<WorkspaceConfiguration>(workspaceRoot.resolve("forge.json").toFile())
+            .namedInputs
+        issues = ConfigLinter(projectGraph, namedInputs).lint()
+    }
+
+    @Test
+    fun `should report every problem in one pass`() {
+        val report = issues.map { Triple(it.project, it.target, it.kind) }
+
+        assertEquals(listOf(
+            Triple("api-gateway", "package", ConfigIssueKind.OUTPUT_COLLISION),
+            Triple("api-gateway", "test", ConfigIssueKind.EMPTY_COMMANDS),
+            Triple("api-gateway", "test", ConfigIssueKind.DANGLING_REFERENCE),
+            Triple("go-utils", "build", ConfigIssueKind.DEPENDS_ON_CYCLE),
+            Triple("go-utils", "docs", ConfigIssueKind.DANGLING_REFERENCE),
+            Triple("go-utils", "test", ConfigIssueKind.UNRESOLVED_TOKEN),
+            Triple("go-utils", "test", ConfigIssueKind.DANGLING_REFERENCE)
+        ).sortedWith(compareBy({ it.first }, { it.second }, { it.third })), report)
+    }
+
+    @Test
+    fun `should explain each problem`() {
+        fun message(project: String, target: String, kind: ConfigIssueKind) =
+            issues.single { it.project == project && it.target == target && it.kind == kind }.message
+
+        assertEquals("dependsOn cycle: go-utils:build -> go-utils:generate -> go-utils:build",
+            message("go-utils", "build", ConfigIssueKind.DEPENDS_ON_CYCLE))
+        assertEquals("options.commands[0] contains unknown token {projectRooot} in \"go test {projectRooot}/...\"",
+            message("go-utils", "test", ConfigIssueKind.UNRESOLVED_TOKEN))
+        assertEquals("dependsOn \"docs-site:build\" refers to unknown project 'docs-site'",
+            message("go-utils", "docs", ConfigIssueKind.DANGLING_REFERENCE))
+        assertEquals("input \"^testing\" refers to undefined named input 'testing'",
+            message("go-utils", "test", ConfigIssueKind.DANGLING_REFERENCE))
+        assertEquals("dependsOn \"lint\" refers to a target this project does not have",
+            message("api-gateway", "test", ConfigIssueKind.DANGLING_REFERENCE))
+        assertEquals(
+            "output \"{projectRoot}/bin/api-gateway.tar.gz\" (services/api-gateway/bin/api-gateway.tar.gz) overlaps output \"{projectRoot}/bin\" of api-gateway:build",
+            message("api-gateway", "package", ConfigIssueKind.OUTPUT_COLLISION))
+    }
+
+    @Test
+    fun `should not report a consistent project`() {
+        assertTrue(issues.none { it.project == "logging" }, "logging is configured correctly: $issues")
+    }
+
+    @Test
+    fun `should apply checks to targets inheriting targetDefaults`() {
+        val projectGraph = ProjectDiscovery(workspaceRoot).discoverProjects()
+
+        assertEquals("forge:run-commands", projectGraph.getProject("api-gateway")!!.data.targets["build"]!!.executor)
+        assertTrue(issues.none { it.target == "build" && it.kind == ConfigIssueKind.EMPTY_COMMANDS })
+    }
+}
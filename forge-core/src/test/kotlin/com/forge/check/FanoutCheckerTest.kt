@@ -0,0 +1,74 @@
+package com.forge.check
+
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.fasterxml.jackson.module.kotlin.readValue
+import com.forge.config.FanoutConfiguration
+import com.forge.config.WorkspaceConfiguration
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import java.nio.file.Paths
+
+class FanoutCheckerTest {
+
+    private lateinit var fanout: FanoutConfiguration
+    private lateinit var projectGraph: ProjectGraph
+
+    @BeforeEach
+    fun setup() {
+        val resourcesPath = this::class.java.classLoader.getResource("test-fanout-workspace/forge.json")?.toURI()
+        assertNotNull(resourcesPath, "Test workspace not found in resources")
+        fanout = jacksonObjectMapper().readValue<WorkspaceConfiguration>(Paths.get(resourcesPath!!).toFile()).fanout
+
+        // go-utils and logging are used by every service; api-gateway by one
+        val services = listOf("api-gateway", "orders", "payments", "users", "notifications")
+        val edges = services.associateWith { service ->
+            listOf("go-utils", "logging").map { ProjectGraphDependency(service, it, DependencyType.STATIC) }
+        } + mapOf(
+            "edge-proxy" to listOf(ProjectGraphDependency("edge-proxy", "api-gateway", DependencyType.STATIC)),
+            "go-utils" to emptyList(),
+            "logging" to listOf(ProjectGraphDependency("logging", "go-utils", DependencyType.STATIC))
+        )
+        projectGraph = ProjectGraph(
+            nodes = edges.keys.associateWith { ProjectGraphNode(it, "library", ProjectConfiguration(name = it, root = it)) },
+            dependencies = edges
+        )
+    }
+
+    @Test
+    fun `should report projects exceeding the limit with their dependents`() {
+        val violations = FanoutChecker(projectGraph, fanout.max!!).check()
+
+        assertEquals(listOf("go-utils", "logging"), violations.map { it.project })
+        val goUtils = violations.first()
+        assertEquals(6, goUtils.count)
+        assertEquals(3, goUtils.limit)
+        assertEquals(listOf("api-gateway", "logging", "notifications", "orders", "payments", "users"), goUtils.dependents)
+    }
+
+    @Test
+    fun `should apply per-project overrides from config`() {
+        val checker = FanoutChecker(projectGraph, fanout.max!!, fanout.overrides)
+
+        val violations = checker.check()
+
+        assertEquals(listOf("go-utils"), violations.map { it.project })
+        assertEquals(10, checker.limitFor("logging"))
+    }
+
+    @Test
+    fun `should pass when every project is within the limit`() {
+        assertTrue(FanoutChecker(projectGraph, 6).check().isEmpty())
+    }
+
+    @Test
+    fun `should count only direct dependents`() {
+        assertEquals(setOf("edge-proxy"), projectGraph.getDependents("api-gateway"))
+        assertTrue(projectGraph.getDependents("edge-proxy").isEmpty())
+    }
+}
@@ -0,0 +1,66 @@
+package com.forge.check
+
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphNode
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import java.nio.file.Path
+import java.nio.file.Paths
+
+class ImportPathCheckerTest {
+
+    private lateinit var workspaceRoot: Path
+    private lateinit var issues: List<ImportIssue>
+
+    @BeforeEach
+    fun setup() {
+        val resourcesPath = this::class.java.classLoader.getResource("test-import-workspace")?.toURI()
+        assertNotNull(resourcesPath, "Test workspace not found in resources")
+        workspaceRoot = Paths.get(resourcesPath!!)
+
+        val projectGraph = ProjectGraph(
+            nodes = mapOf(
+                "go-utils" to project("go-utils", "libs/go-utils"),
+                "payments" to project("payments", "services/payments")
+            ),
+            dependencies = mapOf("go-utils" to emptyList(), "payments" to emptyList())
+        )
+        issues = ImportPathChecker(workspaceRoot, projectGraph).check()
+    }
+
+    @Test
+    fun `should report every mismatch in the copied service`() {
+        val report = issues.map { Triple(it.file, it.line, it.kind) }
+
+        assertEquals(listOf(
+            Triple("services/payments/handlers/handlers.go", 4, ImportIssueKind.FOREIGN_INTERNAL_IMPORT),
+            Triple("services/payments/handlers/handlers.go", 5, ImportIssueKind.UNKNOWN_MODULE),
+            Triple("services/payments/handlers/handlers.go", 6, ImportIssueKind.MISSING_PACKAGE),
+            Triple("services/payments/handlers/routes.go", 1, ImportIssueKind.PACKAGE_NAME_MISMATCH),
+            Triple("services/payments/internal/db/db.go", 1, ImportIssueKind.CANONICAL_IMPORT_MISMATCH)
+        ), report)
+    }
+
+    @Test
+    fun `should explain the expected import path`() {
+        val canonical = issues.single { it.kind == ImportIssueKind.CANONICAL_IMPORT_MISMATCH }
+
+        assertEquals("payments", canonical.project)
+        assertEquals(
+            "package import comment \"github.com/example/orders/internal/db\" should be \"github.com/example/payments/internal/db\"",
+            canonical.message
+        )
+    }
+
+    @Test
+    fun `should not report consistent modules`() {
+        assertTrue(issues.none { it.project == "go-utils" }, "go-utils imports are consistent: $issues")
+        assertTrue(issues.none { it.file.endsWith("main.go") }, "main.go imports are consistent: $issues")
+    }
+
+    private fun project(name: String, root: String): ProjectGraphNode {
+        return ProjectGraphNode(name, "library", ProjectConfiguration(name = name, root = root))
+    }
+}
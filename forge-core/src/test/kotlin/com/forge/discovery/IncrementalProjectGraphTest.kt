@@ -0,0 +1,241 @@
+package com.forge.discovery
+
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.inference.CreateDependenciesContext
+import com.forge.inference.CreateNodesContext
+import com.forge.inference.CreateNodesResult
+import com.forge.inference.GoSource
+import com.forge.inference.InferenceEngine
+import com.forge.inference.RawProjectGraphDependency
+import com.forge.plugin.ForgePlugin
+import com.forge.plugin.PluginMetadata
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.deleteExisting
+import kotlin.io.path.extension
+import kotlin.io.path.isRegularFile
+import kotlin.io.path.readText
+import kotlin.io.path.writeText
+
+class IncrementalProjectGraphTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private val plugin = GoImportsPlugin()
+    private lateinit var incremental: IncrementalProjectGraph
+
+    @BeforeEach
+    fun setup() {
+        module("libs/go-utils", "github.com/example/go-utils")
+        writeFile("libs/go-utils/utils.go", goFile("utils"))
+        module("libs/logging", "github.com/example/logging")
+        writeFile("libs/logging/log.go", goFile("logging"))
+        module("services/api-gateway", "github.com/example/api-gateway")
+        writeFile("services/api-gateway/main.go", goFile("main", "github.com/example/go-utils"))
+        module("services/edge", "github.com/example/edge")
+        writeFile("services/edge/main.go", goFile("main", "github.com/example/api-gateway"))
+
+        incremental = IncrementalProjectGraph(workspaceRoot, InferenceEngine(plugins = listOf(plugin)))
+        incremental.build()
+    }
+
+    @Test
+    fun `should match a full recompute after the initial build`() {
+        assertGraphMatchesFullRecompute()
+        assertEquals(setOf("go-utils"), incremental.graph.getTransitiveDependencies("api-gateway"))
+    }
+
+    @Test
+    fun `should add an edge for a new import and propagate it to dependents`() {
+        plugin.reset()
+        writeFile("services/api-gateway/main.go",
+            goFile("main", "github.com/example/go-utils", "github.com/example/logging"))
+
+        val graph = incremental.update(listOf("services/api-gateway/main.go"))
+
+        assertEquals(1, plugin.createNodesCalls, "Only the module owning the source is re-inferred")
+        assertEquals(listOf(setOf("api-gateway")), plugin.processedSources)
+        assertEquals(listOf("go-utils", "logging"), graph.getDependencies("api-gateway").map { it.target })
+        assertTrue("logging" in graph.getTransitiveDependencies("edge"))
+        assertGraphMatchesFullRecompute()
+    }
+
+    @Test
+    fun `should drop an edge when an import is removed`() {
+        writeFile("services/api-gateway/main.go", goFile("main"))
+
+        val graph = incremental.update(listOf("services/api-gateway/main.go"))
+
+        assertTrue(graph.getDependencies("api-gateway").isEmpty())
+        assertEquals(setOf("api-gateway"), graph.getTransitiveDependencies("edge"))
+        assertGraphMatchesFullRecompute()
+    }
+
+    @Test
+    fun `should re-infer only the changed configuration file`() {
+        plugin.reset()
+        writeFile("libs/logging/go.mod", "module github.com/example/logging\n\ngo 1.22\n")
+
+        incremental.update(listOf("libs/logging/go.mod"))
+
+        assertEquals(1, plugin.createNodesCalls)
+        assertGraphMatchesFullRecompute()
+    }
+
+    @Test
+    fun `should re-resolve dependents when a module path changes`() {
+        module("libs/go-utils", "github.com/example/utils")
+
+        val graph = incremental.update(listOf("libs/go-utils/go.mod"))
+
+        assertTrue(graph.getDependencies("api-gateway").isEmpty(), "The old module path no longer resolves")
+        assertTrue(graph.hasProject("utils"))
+        assertFalse(graph.hasProject("go-utils"))
+        assertGraphMatchesFullRecompute()
+    }
+
+    @Test
+    fun `should handle added and removed projects`() {
+        module("libs/metrics", "github.com/example/metrics")
+        writeFile("libs/metrics/metrics.go", goFile("metrics", "github.com/example/logging"))
+        writeFile("services/edge/main.go", goFile("main", "github.com/example/api-gateway", "github.com/example/metrics"))
+        incremental.update(listOf("libs/metrics/go.mod", "libs/metrics/metrics.go", "services/edge/main.go"))
+
+        assertEquals(setOf("api-gateway", "go-utils", "logging", "metrics"),
+            incremental.graph.getTransitiveDependencies("edge"))
+        assertGraphMatchesFullRecompute()
+
+        workspaceRoot.resolve("libs/logging/go.mod").deleteExisting()
+        val graph = incremental.update(listOf("libs/logging/go.mod"))
+
+        assertFalse(graph.hasProject("logging"))
+        assertTrue(graph.getDependencies("metrics").isEmpty())
+        assertGraphMatchesFullRecompute()
+    }
+
+    @Test
+    fun `should not re-infer a workspace root module for changes in nested projects`() {
+        writeFile("go.mod", "module github.com/example/monorepo\n\ngo 1.21\n")
+        writeFile("tools/gen.go", goFile("main"))
+        incremental = IncrementalProjectGraph(workspaceRoot, InferenceEngine(plugins = listOf(plugin)))
+        incremental.build()
+        plugin.reset()
+
+        writeFile("services/api-gateway/main.go", goFile("main", "github.com/example/go-utils") + "\nfunc main() {}\n")
+        incremental.update(listOf("services/api-gateway/main.go"))
+
+        assertEquals(1, plugin.createNodesCalls, "Only services/api-gateway/go.mod should be re-inferred")
+        assertGraphMatchesFullRecompute()
+
+        plugin.reset()
+        writeFile("tools/gen.go", goFile("main", "github.com/example/logging"))
+        incremental.update(listOf("tools/gen.go"))
+
+        assertEquals(1, plugin.createNodesCalls, "A file owned by the root module re-infers the root go.mod")
+        assertGraphMatchesFullRecompute()
+    }
+
+    @Test
+    fun `should ignore changes outside any project`() {
+        plugin.reset()
+        writeFile("docs/README.md", "# Docs\n")
+
+        incremental.update(listOf("docs/README.md"))
+
+        assertEquals(0, plugin.createNodesCalls)
+        assertTrue(plugin.processedSources.isEmpty())
+        assertGraphMatchesFullRecompute()
+    }
+
+    private fun assertGraphMatchesFullRecompute() {
+        val full = fullRecompute()
+        assertEquals(full.nodes, incremental.graph.nodes)
+        assertEquals(full.dependencies, incremental.graph.dependencies)
+    }
+
+    private fun fullRecompute(): ProjectGraph {
+        val engine = InferenceEngine(plugins = listOf(GoImportsPlugin()))
+        return ProjectDiscovery(workspaceRoot, enableInference = true, inferenceEngine = engine).discoverProjects()
+    }
+
+    private fun module(root: String, modulePath: String) {
+        writeFile("$root/go.mod", "module $modulePath\n\ngo 1.21\n")
+    }
+
+    private fun goFile(packageName: String, vararg imports: String): String = buildString {
+        appendLine("package $packageName")
+        if (imports.isNotEmpty()) {
+            appendLine()
+            appendLine("import (")
+            imports.forEach { appendLine("    \"$it\"") }
+            appendLine(")")
+        }
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+
+    /**
+     * Infers a project per go.mod and an edge per import of another workspace module
+     */
+    private class GoImportsPlugin : ForgePlugin {
+        var createNodesCalls = 0
+            private set
+        val processedSources = mutableListOf<Set<String>>()
+
+        override val metadata = PluginMetadata(
+            id = "test.go-imports",
+            name = "Test Go imports plugin",
+            version = "1.0.0",
+            description = "Infers Go projects and import edges for tests",
+            createNodesPattern = "**/go.mod",
+            supportedFiles = listOf("go.mod")
+        )
+
+        fun reset() {
+            createNodesCalls = 0
+            processedSources.clear()
+        }
+
+        override fun createNodes(configFiles: List<String>, options: Any?, context: CreateNodesContext): CreateNodesResult {
+            createNodesCalls++
+            val projects = configFiles.associate { configFile ->
+                val goModPath = Path.of(configFile)
+                val name = GoSource.modulePath(goModPath.readText())!!.substringAfterLast("/")
+                name to ProjectConfiguration(name = name, root = context.workspaceRoot.relativize(goModPath.parent).toString())
+            }
+            return CreateNodesResult(projects = projects)
+        }
+
+        override fun createDependencies(options: Any?, context: CreateDependenciesContext): List<RawProjectGraphDependency> {
+            context.projectsToProcess?.let { processedSources.add(it) }
+
+            val projectsByModule = context.projects.values.associate { project ->
+                GoSource.modulePath(context.workspaceRoot.resolve(project.root).resolve("go.mod").readText()) to project.name
+            }
+
+            return context.sourceProjects().flatMap { project ->
+                val projectDir = context.workspaceRoot.resolve(project.root)
+                val imports = Files.walk(projectDir).use { stream ->
+                    stream.filter { it.isRegularFile() && it.extension == "go" }.toList()
+                }.flatMap { GoSource.imports(it.readText()) }.map { it.path }
+
+                imports.mapNotNull { projectsByModule[it] }
+                    .filter { it != project.name }
+                    .distinct()
+                    .sorted()
+                    .map { RawProjectGraphDependency(project.name, it, DependencyType.STATIC) }
+            }
+        }
+    }
+}
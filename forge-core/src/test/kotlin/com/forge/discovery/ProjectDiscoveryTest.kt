@@ -1,11 +1,21 @@
 package com.forge.discovery
 
 import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.TargetConfiguration
+import com.forge.inference.CreateNodesContext
+import com.forge.inference.CreateNodesResult
+import com.forge.inference.InferenceEngine
+import com.forge.plugin.ForgePlugin
+import com.forge.plugin.PluginMetadata
 import org.junit.jupiter.api.Test
 import org.junit.jupiter.api.BeforeEach
 import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
 import java.nio.file.Path
 import java.nio.file.Paths
+import kotlin.io.path.writeText
 
 class ProjectDiscoveryTest {
     
@@ -231,4 +241,63 @@ class ProjectDiscoveryTest {
         println("ui: ${uiDependents.joinToString(", ")}")
         println("web: ${webDependents.joinToString(", ")}")
     }
-}
\ No newline at end of file
+    
+    @Test
+    fun `should let project json targets win over inferred targets`(@TempDir workspaceRoot: Path) {
+        val projectDir = workspaceRoot.resolve("services/payments")
+        Files.createDirectories(projectDir)
+        projectDir.resolve("go.mod").writeText("module github.com/example/payments\n")
+        projectDir.resolve("project.json").writeText(
+            """
+            {
+              "name": "payments",
+              "tags": ["scope:payments"],
+              "targets": {
+                "smoke": {
+                  "executor": "forge:run-commands",
+                  "options": { "commands": ["./scripts/smoke.sh"] }
+                }
+              }
+            }
+            """.trimIndent()
+        )
+        val inferenceEngine = InferenceEngine(plugins = listOf(AnnotatedGoPlugin()))
+        
+        val project = ProjectDiscovery(workspaceRoot, inferenceEngine = inferenceEngine)
+            .discoverProjects()
+            .getProject("payments")!!
+            .data
+        
+        assertEquals(listOf("./scripts/smoke.sh"), project.getTarget("smoke")!!.options["commands"],
+            "The hand-written target should replace the annotated one")
+        assertTrue(project.hasTarget("build"), "Inferred targets not in project.json should be kept")
+        assertEquals(listOf("go", "team:payments", "scope:payments"), project.tags)
+    }
+    
+    /**
+     * Infers a Go project the way the Go plugin does after folding in a //forge:target smoke annotation
+     */
+    private class AnnotatedGoPlugin : ForgePlugin {
+        override val metadata = PluginMetadata(
+            id = "test.annotated-go",
+            name = "Test annotated Go plugin",
+            version = "1.0.0",
+            description = "Infers a Go project with an annotated target for tests",
+            createNodesPattern = "**/go.mod",
+            supportedFiles = listOf("go.mod")
+        )
+        
+        override fun createNodes(configFiles: List<String>, options: Any?, context: CreateNodesContext): CreateNodesResult {
+            val project = ProjectConfiguration(
+                name = "payments",
+                root = "services/payments",
+                tags = listOf("go", "team:payments"),
+                targets = mapOf(
+                    "build" to TargetConfiguration(options = mapOf("commands" to listOf("go build ./..."))),
+                    "smoke" to TargetConfiguration(options = mapOf("commands" to listOf("go run ./cmd/smoke")))
+                )
+            )
+            return CreateNodesResult(projects = mapOf(project.name to project))
+        }
+    }
+}
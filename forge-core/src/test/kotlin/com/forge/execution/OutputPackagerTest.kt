@@ -0,0 +1,215 @@
+package com.forge.execution
+
+import build.bazel.remote.execution.v2.ActionResult
+import build.bazel.remote.execution.v2.OutputFile
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import com.forge.execution.remote.FakeActionCacheService
+import com.forge.execution.remote.FakeCasService
+import com.forge.execution.remote.FakeExecutionService
+import com.forge.execution.remote.RemoteExecutionConfig
+import com.forge.execution.remote.RemoteExecutionExecutor
+import com.forge.execution.remote.fakeServices
+import com.forge.graph.TaskStatus
+import com.google.protobuf.ByteString
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.assertThrows
+import org.junit.jupiter.api.io.TempDir
+import java.io.DataInputStream
+import java.io.RandomAccessFile
+import java.nio.file.Files
+import java.nio.file.Path
+import java.util.zip.GZIPInputStream
+import kotlin.io.path.exists
+import kotlin.io.path.inputStream
+import kotlin.io.path.writeText
+
+class OutputPackagerTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private val project = ProjectConfiguration(name = "api-gateway", root = "services/api-gateway")
+
+    private val build = TargetConfiguration(
+        executor = "go",
+        outputs = listOf("{projectRoot}/bin/**/*", "{workspaceRoot}/dist/{projectName}/build-info.json")
+    )
+
+    private val remoteBuild = TargetConfiguration(
+        executor = "forge:run-commands",
+        options = mapOf("commands" to listOf("go build -o bin/api-gateway .")),
+        outputs = listOf("{projectRoot}/bin/api-gateway")
+    )
+
+    private val remoteProject = project.copy(targets = mapOf("build" to remoteBuild))
+
+    private val cas = FakeCasService()
+    private val execution = FakeExecutionService()
+    private val actionCache = FakeActionCacheService()
+
+    @Test
+    fun `should package exactly the declared outputs relative to the project root`() {
+        writeFile("services/api-gateway/bin/api-gateway", "binary")
+        writeFile("services/api-gateway/bin/tools/migrate", "migrate")
+        writeFile("services/api-gateway/main.go", "package main\n")
+        writeFile("dist/api-gateway/build-info.json", "{}")
+        writeFile("dist/api-gateway/other.json", "{}")
+        val archive = workspaceRoot.resolve("out/api-gateway.tar.gz")
+
+        val packaged = OutputPackager(workspaceRoot).packageOutputs(project, build, archive)
+
+        val expected = mapOf(
+            "bin/api-gateway" to "binary",
+            "bin/tools/migrate" to "migrate",
+            "dist/api-gateway/build-info.json" to "{}"
+        )
+        assertEquals(expected.keys, packaged.map { it.path }.toSet())
+        assertEquals(expected, readTarball(archive))
+    }
+
+    @Test
+    fun `should package every file below a declared output directory`() {
+        writeFile("services/api-gateway/coverage/index.html", "<html/>")
+        writeFile("services/api-gateway/coverage/lcov/lcov.info", "TN:")
+        val target = TargetConfiguration(executor = "go", outputs = listOf("{projectRoot}/coverage"))
+        val archive = workspaceRoot.resolve("coverage.tar.gz")
+
+        OutputPackager(workspaceRoot).packageOutputs(project, target, archive)
+
+        assertEquals(setOf("coverage/index.html", "coverage/lcov/lcov.info"), readTarball(archive).keys)
+    }
+
+    @Test
+    fun `should fail when a declared output is missing`() {
+        writeFile("services/api-gateway/bin/api-gateway", "binary")
+        val archive = workspaceRoot.resolve("out.tar.gz")
+
+        val error = assertThrows<MissingOutputsException> {
+            OutputPackager(workspaceRoot).packageOutputs(project, build, archive)
+        }
+
+        assertEquals(listOf("{workspaceRoot}/dist/{projectName}/build-info.json"), error.missing)
+        assertFalse(archive.exists(), "No partial archive is written")
+    }
+
+    @Test
+    fun `should keep names longer than the tar name field`() {
+        val longDir = "a".repeat(60) + "/" + "b".repeat(60)
+        writeFile("services/api-gateway/bin/$longDir/tool", "tool")
+
+        val archive = workspaceRoot.resolve("out.tar.gz")
+        OutputPackager(workspaceRoot).packageOutputs(project, TargetConfiguration(executor = "go", outputs = listOf("{projectRoot}/bin")), archive)
+
+        assertEquals(setOf("bin/$longDir/tool"), readTarball(archive).keys)
+    }
+
+    @Test
+    fun `should package the outputs a remote run downloaded`() {
+        val archive = workspaceRoot.resolve("out/api-gateway.tar.gz")
+
+        val result = runRemotely().results.getValue("api-gateway:build")
+        OutputPackager(workspaceRoot).packageOutputs(remoteProject, remoteBuild, archive)
+
+        assertEquals(TaskStatus.COMPLETED, result.status)
+        assertEquals(mapOf("bin/api-gateway" to "binary"), readTarball(archive))
+    }
+
+    @Test
+    fun `should package the outputs of a cached build in a fresh checkout`() {
+        runRemotely()
+        workspaceRoot.resolve("services/api-gateway/bin").toFile().deleteRecursively()
+        val archive = workspaceRoot.resolve("out/api-gateway.tar.gz")
+
+        val result = runRemotely().results.getValue("api-gateway:build")
+        OutputPackager(workspaceRoot).packageOutputs(remoteProject, remoteBuild, archive)
+
+        assertEquals(TaskStatus.CACHED, result.status)
+        assertEquals(1, execution.executed.size, "The second build should come from cache")
+        assertEquals(mapOf("bin/api-gateway" to "binary"), readTarball(archive))
+    }
+
+    @Test
+    fun `should reject files too large for a tar entry`() {
+        val large = workspaceRoot.resolve("services/api-gateway/bin/api-gateway")
+        Files.createDirectories(large.parent)
+        RandomAccessFile(large.toFile(), "rw").use { it.setLength(8L shl 30) }
+        val archive = workspaceRoot.resolve("out.tar.gz")
+
+        assertThrows<IllegalArgumentException> {
+            OutputPackager(workspaceRoot).packageOutputs(project, TargetConfiguration(executor = "go", outputs = listOf("{projectRoot}/bin")), archive)
+        }
+        assertFalse(archive.exists(), "No partial archive is written")
+    }
+
+    @Test
+    fun `should reject names that are not ascii`() {
+        writeFile("services/api-gateway/bin/café", "binary")
+        val archive = workspaceRoot.resolve("out.tar.gz")
+
+        assertThrows<IllegalArgumentException> {
+            OutputPackager(workspaceRoot).packageOutputs(project, TargetConfiguration(executor = "go", outputs = listOf("{projectRoot}/bin")), archive)
+        }
+        assertFalse(archive.exists(), "No partial archive is written")
+    }
+
+    /**
+     * Build the api-gateway binary on fake remote services, downloading its outputs as `run --package` does
+     */
+    private fun runRemotely(): ExecutionResults {
+        val graph = ProjectGraph(
+            nodes = mapOf("api-gateway" to ProjectGraphNode("api-gateway", remoteProject.projectType, remoteProject)),
+            dependencies = mapOf("api-gateway" to emptyList())
+        )
+        execution.result = ActionResult.newBuilder()
+            .setExitCode(0)
+            .addOutputFiles(OutputFile.newBuilder()
+                .setPath("services/api-gateway/bin/api-gateway")
+                .setDigest(cas.store(ByteString.copyFromUtf8("binary"))))
+            .build()
+
+        val executor = RemoteExecutionExecutor(workspaceRoot, graph, RemoteExecutionConfig(),
+            fakeServices(execution, cas, actionCache))
+        try {
+            val plan = TaskGraphBuilder(graph).buildTaskGraph("build").getExecutionPlan()
+            return executor.execute(plan, downloadOutputs = setOf("api-gateway:build"))
+        } finally {
+            executor.close()
+        }
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+
+    /**
+     * Read a ustar archive into a map of entry name to content
+     */
+    private fun readTarball(archive: Path): Map<String, String> {
+        val entries = linkedMapOf<String, String>()
+        DataInputStream(GZIPInputStream(archive.inputStream())).use { input ->
+            val header = ByteArray(512)
+            while (true) {
+                input.readFully(header)
+                if (header.all { it == 0.toByte() }) break
+
+                val name = field(header, 0, 100)
+                val prefix = field(header, 345, 155)
+                val size = field(header, 124, 12).trim().toLong(8).toInt()
+                val content = ByteArray(size).also { input.readFully(it) }
+                input.skipBytes((512 - size % 512) % 512)
+
+                entries[if (prefix.isEmpty()) name else "$prefix/$name"] = String(content)
+            }
+        }
+        return entries
+    }
+
+    private fun field(header: ByteArray, offset: Int, length: Int): String =
+        String(header, offset, length).substringBefore('\u0000')
+}
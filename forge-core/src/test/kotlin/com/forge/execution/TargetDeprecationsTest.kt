@@ -0,0 +1,52 @@
+package com.forge.execution
+
+import com.forge.core.ProjectGraph
+import com.forge.discovery.ProjectDiscovery
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import java.nio.file.Paths
+
+class TargetDeprecationsTest {
+
+    private lateinit var projectGraph: ProjectGraph
+
+    @BeforeEach
+    fun setup() {
+        val resourcesPath = this::class.java.classLoader.getResource("test-deprecation-workspace")?.toURI()
+        assertNotNull(resourcesPath, "Test workspace not found in resources")
+        projectGraph = ProjectDiscovery(Paths.get(resourcesPath!!)).discoverProjects()
+    }
+
+    @Test
+    fun `should warn once with the replacement for a deprecated target`() {
+        val plan = TaskGraphBuilder(projectGraph).buildTaskGraph("build-all").getExecutionPlan()
+        assertEquals(2, plan.totalTasks)
+
+        val usages = TargetDeprecations.find(plan)
+
+        assertEquals(1, usages.size, "One warning per run, not per task")
+        assertEquals("build", usages.single().deprecation.replacement)
+        assertEquals(
+            "Target 'build-all' is deprecated, use 'build' instead (api-gateway, go-utils)",
+            usages.single().warning()
+        )
+    }
+
+    @Test
+    fun `should include the deprecation message declared on the target`() {
+        val plan = TaskGraphBuilder(projectGraph).buildTaskGraphForProjects("image", listOf("api-gateway")).getExecutionPlan()
+
+        assertEquals(
+            listOf("Target 'image' is deprecated, use 'docker-build' instead: images are now built by the Docker plugin (api-gateway)"),
+            TargetDeprecations.find(plan).map { it.warning() }
+        )
+    }
+
+    @Test
+    fun `should not warn for targets that are not deprecated`() {
+        val plan = TaskGraphBuilder(projectGraph).buildTaskGraph("build").getExecutionPlan()
+
+        assertTrue(TargetDeprecations.find(plan).isEmpty())
+    }
+}
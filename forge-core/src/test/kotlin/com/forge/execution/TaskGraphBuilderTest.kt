@@ -1,5 +1,9 @@
 package com.forge.execution
 
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
 import com.forge.discovery.ProjectDiscovery
 import org.junit.jupiter.api.Test
 import org.junit.jupiter.api.BeforeEach
@@ -236,4 +240,55 @@ class TaskGraphBuilderTest {
         assertTrue(executionPlan.maxParallelism > 0, "Should have parallelism")
         assertEquals(taskGraph.size(), executionPlan.totalTasks, "Task counts should match")
     }
-}
\ No newline at end of file
+    
+    @Test
+    fun `should create one task per matrix entry`() {
+        val taskGraph = TaskGraphBuilder(matrixProjectGraph()).buildTaskGraph("test")
+        
+        assertEquals(
+            setOf("api:test[DB=postgres,GO=1.21]", "api:test[DB=postgres,GO=1.22]", "api:test[DB=mysql,GO=1.21]", "api:test[DB=mysql,GO=1.22]"),
+            taskGraph.getAllTasks().map { it.id }.toSet()
+        )
+        
+        val task = taskGraph.getTask("api:test[DB=mysql,GO=1.22]")!!
+        assertEquals(mapOf("DB" to "mysql", "GO" to "1.22"), task.matrix)
+        assertEquals(mapOf("CGO_ENABLED" to "0", "DB" to "mysql", "GO" to "1.22"), task.target.options["env"])
+        assertEquals("test", task.targetName)
+        assertTrue(task.isCacheable(), "Matrix tasks should be cacheable")
+        assertEquals(4, taskGraph.getAllTasks().mapNotNull { it.hash }.toSet().size, "Each matrix task should have its own hash")
+    }
+    
+    @Test
+    fun `should depend on every matrix entry of a dependency target`() {
+        val taskGraph = TaskGraphBuilder(matrixProjectGraph()).buildTaskGraph("e2e")
+        
+        assertEquals(5, taskGraph.size())
+        assertEquals(
+            listOf("api:test[DB=mysql,GO=1.21]", "api:test[DB=mysql,GO=1.22]", "api:test[DB=postgres,GO=1.21]", "api:test[DB=postgres,GO=1.22]"),
+            taskGraph.getDependencies("api:e2e").sorted()
+        )
+    }
+    
+    private fun matrixProjectGraph(): ProjectGraph {
+        val project = ProjectConfiguration(
+            name = "api",
+            root = "services/api",
+            targets = mapOf(
+                "test" to TargetConfiguration(
+                    executor = "forge:run-commands",
+                    options = mapOf("commands" to listOf("go test ./..."), "env" to mapOf("CGO_ENABLED" to "0")),
+                    matrix = mapOf("DB" to listOf("postgres", "mysql"), "GO" to listOf("1.21", "1.22"))
+                ),
+                "e2e" to TargetConfiguration(
+                    executor = "forge:run-commands",
+                    options = mapOf("commands" to listOf("go test ./e2e/...")),
+                    dependsOn = listOf("test")
+                )
+            )
+        )
+        return ProjectGraph(
+            nodes = mapOf("api" to ProjectGraphNode("api", "application", project)),
+            dependencies = mapOf("api" to emptyList())
+        )
+    }
+}
@@ -0,0 +1,97 @@
+package com.forge.execution
+
+import com.forge.core.RetryPolicy
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+
+class TaskRetryTest {
+
+    private val sleeps = mutableListOf<Long>()
+    private val retry = TaskRetry { sleeps.add(it) }
+
+    private val networkPolicy = RetryPolicy(
+        maxRetries = 3,
+        exitCodes = listOf(75),
+        outputPatterns = listOf("connection reset|i/o timeout"),
+        delayMillis = 100
+    )
+
+    @Test
+    fun `should retry a failure matching an output pattern until it succeeds`() {
+        val executor = FakeExecutor(
+            ProcessResult(1, "", "dial tcp 10.0.0.1:443: i/o timeout"),
+            ProcessResult(0, "ok", "")
+        )
+
+        val outcome = retry.run("api:build", networkPolicy, executor::run)
+
+        assertEquals(0, outcome.result.exitCode)
+        assertEquals(2, outcome.attempts)
+        assertEquals(2, executor.calls)
+        assertEquals(listOf(100L), sleeps)
+    }
+
+    @Test
+    fun `should retry a failure with a retryable exit code`() {
+        val executor = FakeExecutor(ProcessResult(75, "", ""), ProcessResult(0, "ok", ""))
+
+        val outcome = retry.run("api:build", networkPolicy, executor::run)
+
+        assertEquals(2, outcome.attempts)
+    }
+
+    @Test
+    fun `should fail immediately on a non-retryable failure`() {
+        val executor = FakeExecutor(
+            ProcessResult(2, "main.go:3: undefined: Foo", ""),
+            ProcessResult(0, "ok", "")
+        )
+
+        val outcome = retry.run("api:build", networkPolicy, executor::run)
+
+        assertEquals(2, outcome.result.exitCode)
+        assertEquals(1, outcome.attempts)
+        assertEquals(1, executor.calls)
+        assertTrue(sleeps.isEmpty())
+    }
+
+    @Test
+    fun `should stop after max retries`() {
+        val executor = FakeExecutor(ProcessResult(75, "", "connection reset by peer"))
+
+        val outcome = retry.run("api:build", networkPolicy, executor::run)
+
+        assertEquals(75, outcome.result.exitCode)
+        assertEquals(4, outcome.attempts)
+    }
+
+    @Test
+    fun `should retry any failure when no conditions are given`() {
+        val executor = FakeExecutor(ProcessResult(2, "", ""), ProcessResult(0, "", ""))
+
+        val outcome = retry.run("api:build", RetryPolicy(maxRetries = 1), executor::run)
+
+        assertEquals(2, outcome.attempts)
+    }
+
+    @Test
+    fun `should not retry without a policy`() {
+        val executor = FakeExecutor(ProcessResult(75, "", ""), ProcessResult(0, "", ""))
+
+        val outcome = retry.run("api:build", null, executor::run)
+
+        assertEquals(1, outcome.attempts)
+        assertEquals(75, outcome.result.exitCode)
+    }
+
+    /**
+     * Returns the given results in order, repeating the last one
+     */
+    private class FakeExecutor(vararg results: ProcessResult) {
+        private val results = results.toList()
+        var calls = 0
+            private set
+
+        fun run(): ProcessResult = results[minOf(calls++, results.size - 1)]
+    }
+}
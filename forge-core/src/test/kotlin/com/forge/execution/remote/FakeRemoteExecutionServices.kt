@@ -0,0 +1,99 @@
+package com.forge.execution.remote
+
+import build.bazel.remote.execution.v2.*
+import com.google.longrunning.Operation
+import com.google.protobuf.Any
+import com.google.protobuf.ByteString
+import com.google.rpc.Status
+import io.grpc.ManagedChannelBuilder
+import kotlinx.coroutines.flow.Flow
+import kotlinx.coroutines.flow.flowOf
+import java.security.MessageDigest
+
+/**
+ * Remote execution services backed by in-memory fakes, for driving a real [RemoteExecutionExecutor] in tests
+ */
+internal fun fakeServices(
+    execution: RemoteExecutionService = FakeExecutionService(),
+    cas: ContentAddressableStorageService = FakeCasService(),
+    actionCache: ActionCacheService = FakeActionCacheService()
+) = RemoteExecutionServices(
+    execution = execution,
+    cas = cas,
+    actionCache = actionCache,
+    channel = ManagedChannelBuilder.forTarget("localhost:0").usePlaintext().build()
+)
+
+/**
+ * Returns the queued results first, one per execution, then [result] for every later execution.
+ * When [error] is set, every operation fails with it instead.
+ */
+internal class FakeExecutionService : RemoteExecutionService {
+    val executed = mutableListOf<ExecuteRequest>()
+    val queued = ArrayDeque<ActionResult>()
+    var result: ActionResult = ActionResult.newBuilder().setExitCode(0).build()
+    var error: Status? = null
+
+    override suspend fun execute(request: ExecuteRequest): Flow<Operation> {
+        executed.add(request)
+        error?.let { return flowOf(Operation.newBuilder().setDone(true).setError(it).build()) }
+        val response = ExecuteResponse.newBuilder()
+            .setResult(queued.removeFirstOrNull() ?: result)
+            .build()
+        return flowOf(Operation.newBuilder().setDone(true).setResponse(Any.pack(response)).build())
+    }
+
+    override suspend fun waitExecution(request: WaitExecutionRequest): Flow<Operation> =
+        throw UnsupportedOperationException()
+
+    override suspend fun getCapabilities(request: GetCapabilitiesRequest): ServerCapabilities =
+        ServerCapabilities.getDefaultInstance()
+}
+
+internal class FakeActionCacheService : ActionCacheService {
+    private val results = mutableMapOf<String, ActionResult>()
+
+    override suspend fun getActionResult(request: GetActionResultRequest): ActionResult? =
+        results[request.actionDigest.hash]
+
+    override suspend fun updateActionResult(request: UpdateActionResultRequest): ActionResult {
+        results[request.actionDigest.hash] = request.actionResult
+        return request.actionResult
+    }
+
+    fun stored(): Collection<ActionResult> = results.values
+}
+
+internal class FakeCasService : ContentAddressableStorageService {
+    val blobs = mutableMapOf<String, ByteString>()
+
+    /**
+     * Store a blob as a remote worker would, returning its digest
+     */
+    fun store(data: ByteString): Digest {
+        val hash = MessageDigest.getInstance("SHA-256").digest(data.toByteArray())
+        val digest = Digest.newBuilder()
+            .setHash(hash.joinToString("") { "%02x".format(it) })
+            .setSizeBytes(data.size().toLong())
+            .build()
+        blobs[digest.hash] = data
+        return digest
+    }
+
+    override suspend fun findMissingBlobs(request: FindMissingBlobsRequest): FindMissingBlobsResponse =
+        FindMissingBlobsResponse.getDefaultInstance()
+
+    override suspend fun batchUpdateBlobs(request: BatchUpdateBlobsRequest): BatchUpdateBlobsResponse =
+        BatchUpdateBlobsResponse.getDefaultInstance()
+
+    override suspend fun batchReadBlobs(request: BatchReadBlobsRequest): BatchReadBlobsResponse {
+        val responses = request.digestsList.mapNotNull { digest ->
+            blobs[digest.hash]?.let { data ->
+                BatchReadBlobsResponse.Response.newBuilder().setDigest(digest).setData(data).build()
+            }
+        }
+        return BatchReadBlobsResponse.newBuilder().addAllResponses(responses).build()
+    }
+
+    override suspend fun getTree(request: GetTreeRequest): Flow<GetTreeResponse> = flowOf()
+}
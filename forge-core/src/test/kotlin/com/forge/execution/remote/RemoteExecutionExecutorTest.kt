@@ -0,0 +1,331 @@
+package com.forge.execution.remote
+
+import build.bazel.remote.execution.v2.*
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import com.forge.core.RetryPolicy
+import com.forge.core.TargetConfiguration
+import com.forge.execution.ExecutionResults
+import com.forge.execution.TaskGraphBuilder
+import com.forge.graph.TaskStatus
+import com.google.protobuf.ByteString
+import com.google.rpc.Status
+import org.junit.jupiter.api.AfterEach
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.exists
+import kotlin.io.path.readText
+import kotlin.io.path.writeText
+
+class RemoteExecutionExecutorTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private val cas = FakeCasService()
+    private val execution = FakeExecutionService()
+    private val actionCache = FakeActionCacheService()
+    private lateinit var projectGraph: ProjectGraph
+    private lateinit var executor: RemoteExecutionExecutor
+
+    @BeforeEach
+    fun setup() {
+        writeFile("libs/go-utils/utils.go", "package utils\n")
+        writeFile("services/api-gateway/main.go", "package main\n")
+
+        useProjects(
+            project("go-utils", "libs/go-utils"),
+            project("api-gateway", "services/api-gateway")
+        )
+    }
+
+    @AfterEach
+    fun tearDown() {
+        executor.close()
+    }
+
+    @Test
+    fun `should replay cached tasks on a normal run`() {
+        run()
+        val results = run()
+
+        assertEquals(2, execution.executed.size)
+        assertTrue(results.results.values.all { it.status == TaskStatus.CACHED })
+    }
+
+    @Test
+    fun `should skip cached tasks and only execute cache misses`() {
+        run()
+        writeFile("libs/go-utils/utils.go", "package utils\n\nfunc Noop() {}\n")
+
+        val results = run(skipCached = true)
+
+        assertEquals(3, execution.executed.size, "Only the cache miss should execute")
+        assertEquals(TaskStatus.SKIPPED, results.results["api-gateway:build"]?.status)
+        assertEquals(TaskStatus.COMPLETED, results.results["go-utils:build"]?.status)
+        assertTrue(results.success)
+    }
+
+    @Test
+    fun `should restore the outputs of a skipped task for a dependent that misses the cache`() {
+        useProjects(
+            project("go-utils", "libs/go-utils", outputs = listOf("{projectRoot}/lib/go-utils.a")),
+            project("api-gateway", "services/api-gateway", dependsOn = listOf("^build")),
+            dependencies = mapOf("api-gateway" to listOf("go-utils"))
+        )
+        execution.result = buildResult("libs/go-utils/lib/go-utils.a", "archive", "compiled go-utils")
+        run()
+        writeFile("services/api-gateway/main.go", "package main\n\nfunc main() {}\n")
+
+        val results = run(skipCached = true)
+
+        assertEquals(TaskStatus.SKIPPED, results.results["go-utils:build"]?.status)
+        assertEquals(TaskStatus.COMPLETED, results.results["api-gateway:build"]?.status)
+        assertEquals("archive", workspaceRoot.resolve("libs/go-utils/lib/go-utils.a").readText(),
+            "The dependent needs the outputs of the skipped task")
+    }
+
+    @Test
+    fun `should not restore the outputs of a skipped task without dependents`() {
+        useProjects(project("go-utils", "libs/go-utils", outputs = listOf("{projectRoot}/lib/go-utils.a")))
+        execution.result = buildResult("libs/go-utils/lib/go-utils.a", "archive", "compiled go-utils")
+        run()
+
+        val result = run(skipCached = true).results.getValue("go-utils:build")
+
+        assertEquals(TaskStatus.SKIPPED, result.status)
+        assertFalse(workspaceRoot.resolve("libs/go-utils/lib/go-utils.a").exists())
+    }
+
+    @Test
+    fun `should execute everything when nothing is cached`() {
+        val results = run(skipCached = true)
+
+        assertEquals(2, execution.executed.size)
+        assertTrue(results.results.values.none { it.wasSkipped() })
+    }
+
+    @Test
+    fun `should download the outputs of a remote execution that are packaged`() {
+        useProjects(project("api-gateway", "services/api-gateway", outputs = listOf("{projectRoot}/bin/api-gateway")))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+
+        val result = run(downloadOutputs = setOf("api-gateway:build")).results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.COMPLETED, result.status)
+        assertEquals("binary v1", workspaceRoot.resolve("services/api-gateway/bin/api-gateway").readText())
+    }
+
+    @Test
+    fun `should fail when the packaged outputs of a remote execution cannot be downloaded`() {
+        useProjects(project("api-gateway", "services/api-gateway", outputs = listOf("{projectRoot}/bin/api-gateway")))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+        cas.blobs.clear()
+
+        val result = run(downloadOutputs = setOf("api-gateway:build")).results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.FAILED, result.status)
+        assertEquals("Failed to download the outputs of api-gateway:build", result.error)
+        assertTrue(actionCache.stored().isEmpty(), "A result without its outputs must not be cached")
+    }
+
+    @Test
+    fun `should not download outputs that are not packaged`() {
+        useProjects(project("api-gateway", "services/api-gateway", outputs = listOf("{projectRoot}/bin/api-gateway")))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+        cas.blobs.clear()
+
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.COMPLETED, result.status, "An unreachable CAS must not fail a run that does not package")
+        assertFalse(workspaceRoot.resolve("services/api-gateway/bin/api-gateway").exists())
+    }
+
+    @Test
+    fun `should only report the cached result by default`() {
+        useProjects(project("api-gateway", "services/api-gateway", outputs = listOf("{projectRoot}/bin/api-gateway")))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+
+        run()
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(1, execution.executed.size)
+        assertEquals(TaskStatus.CACHED, result.status)
+        assertEquals("Cached result", result.output)
+        assertFalse(workspaceRoot.resolve("services/api-gateway/bin/api-gateway").exists(), "Outputs are only restored when cacheOutputs is set")
+    }
+
+    @Test
+    fun `should restore outputs and replay logs when both are enabled`() {
+        useProjects(project("api-gateway", "services/api-gateway",
+            outputs = listOf("{projectRoot}/bin/api-gateway"), cacheOutputs = true, cacheLogs = true))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+
+        run()
+        assertFalse(workspaceRoot.resolve("services/api-gateway/bin/api-gateway").exists())
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(1, execution.executed.size)
+        assertEquals(TaskStatus.CACHED, result.status)
+        assertEquals("binary v1", workspaceRoot.resolve("services/api-gateway/bin/api-gateway").readText())
+        assertEquals("compiled api-gateway", result.output)
+    }
+
+    @Test
+    fun `should restore outputs without replaying stale logs when logs are not cached`() {
+        useProjects(project("api-gateway", "services/api-gateway",
+            outputs = listOf("{projectRoot}/bin/api-gateway"), cacheOutputs = true, cacheLogs = false))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+
+        run()
+        assertFalse(workspaceRoot.resolve("services/api-gateway/bin/api-gateway").exists())
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(1, execution.executed.size, "Outputs should come from cache without re-running")
+        assertEquals(TaskStatus.CACHED, result.status)
+        assertEquals("binary v1", workspaceRoot.resolve("services/api-gateway/bin/api-gateway").readText())
+        assertEquals("Cached result", result.output, "Stale logs must not be replayed")
+        assertTrue(actionCache.stored().all { it.stdoutRaw.isEmpty }, "Logs should not be stored in the action cache")
+    }
+
+    @Test
+    fun `should replay logs without restoring outputs when outputs are not cached`() {
+        useProjects(project("api-gateway", "services/api-gateway",
+            outputs = listOf("{projectRoot}/bin/api-gateway"), cacheOutputs = false, cacheLogs = true))
+        execution.result = buildResult("services/api-gateway/bin/api-gateway", "binary v1", "compiled api-gateway")
+
+        run()
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.CACHED, result.status)
+        assertEquals("compiled api-gateway", result.output)
+        assertFalse(workspaceRoot.resolve("services/api-gateway/bin/api-gateway").exists())
+    }
+
+    @Test
+    fun `should retry a failing remote execution until it succeeds`() {
+        useProjects(project("api-gateway", "services/api-gateway",
+            retry = RetryPolicy(maxRetries = 3, outputPatterns = listOf("i/o timeout"))))
+        repeat(2) { execution.queued.add(failedResult(1, "dial tcp 10.0.0.1:443: i/o timeout")) }
+
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.COMPLETED, result.status)
+        assertEquals(3, result.attempts)
+        assertEquals(3, execution.executed.size)
+    }
+
+    @Test
+    fun `should report an operation that failed on the server`() {
+        useProjects(project("api-gateway", "services/api-gateway"))
+        execution.error = Status.newBuilder().setCode(14).setMessage("worker lost").build()
+
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.FAILED, result.status)
+        assertEquals("Remote execution failed: worker lost", result.error)
+    }
+
+    @Test
+    fun `should fail with the attempt count once retries are exhausted`() {
+        useProjects(project("api-gateway", "services/api-gateway",
+            retry = RetryPolicy(maxRetries = 2, exitCodes = listOf(75))))
+        execution.result = failedResult(75, "temporary failure")
+
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(TaskStatus.FAILED, result.status)
+        assertEquals(3, result.attempts)
+        assertEquals("Command failed with exit code 75 after 3 attempts", result.error)
+        assertTrue(actionCache.stored().isEmpty(), "Failed attempts must not be cached")
+    }
+
+    @Test
+    fun `should not retry a failure the policy does not match`() {
+        useProjects(project("api-gateway", "services/api-gateway",
+            retry = RetryPolicy(maxRetries = 2, exitCodes = listOf(75))))
+        execution.result = failedResult(2, "undefined: handlers.Name")
+
+        val result = run().results.getValue("api-gateway:build")
+
+        assertEquals(1, result.attempts)
+        assertEquals(1, execution.executed.size)
+    }
+
+    private fun run(skipCached: Boolean = false, downloadOutputs: Set<String> = emptySet()): ExecutionResults {
+        val plan = TaskGraphBuilder(projectGraph).buildTaskGraph("build").getExecutionPlan()
+        return executor.execute(plan, skipCached = skipCached, downloadOutputs = downloadOutputs)
+    }
+
+    private fun useProjects(vararg nodes: ProjectGraphNode, dependencies: Map<String, List<String>> = emptyMap()) {
+        if (::executor.isInitialized) executor.close()
+
+        projectGraph = ProjectGraph(
+            nodes = nodes.associateBy { it.name },
+            dependencies = nodes.associate { node ->
+                node.name to dependencies[node.name].orEmpty().map { ProjectGraphDependency(node.name, it, DependencyType.STATIC) }
+            }
+        )
+
+        executor = RemoteExecutionExecutor(workspaceRoot, projectGraph, RemoteExecutionConfig(),
+            fakeServices(execution, cas, actionCache))
+    }
+
+    private fun project(
+        name: String,
+        root: String,
+        outputs: List<String> = emptyList(),
+        cacheOutputs: Boolean? = null,
+        cacheLogs: Boolean? = null,
+        retry: RetryPolicy? = null,
+        dependsOn: List<String> = emptyList()
+    ): ProjectGraphNode {
+        val config = ProjectConfiguration(
+            name = name,
+            root = root,
+            targets = mapOf("build" to TargetConfiguration(
+                executor = "forge:run-commands",
+                options = mapOf("commands" to listOf("go build ./...")),
+                inputs = listOf("{projectRoot}/**/*.go"),
+                outputs = outputs,
+                dependsOn = dependsOn,
+                cacheOutputs = cacheOutputs,
+                cacheLogs = cacheLogs,
+                retry = retry
+            ))
+        )
+        return ProjectGraphNode(name, "library", config)
+    }
+
+    /**
+     * A successful result whose output file was uploaded to CAS by the (fake) remote worker
+     */
+    private fun buildResult(outputPath: String, outputContent: String, stdout: String): ActionResult {
+        val digest = cas.store(ByteString.copyFromUtf8(outputContent))
+
+        return ActionResult.newBuilder()
+            .setExitCode(0)
+            .setStdoutRaw(ByteString.copyFromUtf8(stdout))
+            .addOutputFiles(OutputFile.newBuilder().setPath(outputPath).setDigest(digest).build())
+            .build()
+    }
+
+    private fun failedResult(exitCode: Int, stderr: String): ActionResult =
+        ActionResult.newBuilder()
+            .setExitCode(exitCode)
+            .setStderrRaw(ByteString.copyFromUtf8(stderr))
+            .build()
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+}
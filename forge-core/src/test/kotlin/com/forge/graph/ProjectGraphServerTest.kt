@@ -0,0 +1,93 @@
+package com.forge.graph
+
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import org.junit.jupiter.api.AfterEach
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import java.net.URI
+import java.net.http.HttpClient
+import java.net.http.HttpRequest
+import java.net.http.HttpResponse
+
+class ProjectGraphServerTest {
+
+    private val client = HttpClient.newHttpClient()
+    private val mapper = jacksonObjectMapper()
+    private lateinit var server: ProjectGraphServer
+    private var port = 0
+
+    @BeforeEach
+    fun setup() {
+        server = ProjectGraphServer(graph(listOf("api", "utils"), listOf("api" to "utils")), port = 0)
+        port = server.start()
+    }
+
+    @AfterEach
+    fun tearDown() {
+        server.close()
+    }
+
+    @Test
+    fun `should return the current graph as json`() {
+        val response = get("/api/graph")
+
+        assertEquals(200, response.statusCode())
+        assertEquals("application/json", response.headers().firstValue("Content-Type").orElse(null))
+
+        val body = mapper.readTree(response.body())
+        assertEquals(listOf("api", "utils"), body["projects"].map { it["name"].asText() })
+        assertEquals(listOf("build"), body["projects"][0]["targets"].map { it.asText() })
+        assertEquals("api", body["dependencies"][0]["source"].asText())
+        assertEquals("utils", body["dependencies"][0]["target"].asText())
+        assertEquals("static", body["dependencies"][0]["type"].asText())
+    }
+
+    @Test
+    fun `should serve the updated graph and bump the version`() {
+        val before = mapper.readTree(get("/api/graph/version").body())["version"].asLong()
+
+        server.update(graph(listOf("api", "utils", "web"), listOf("api" to "utils", "web" to "api")))
+
+        val after = mapper.readTree(get("/api/graph/version").body())["version"].asLong()
+        val body = mapper.readTree(get("/api/graph").body())
+        assertTrue(after > before)
+        assertEquals(after, body["version"].asLong())
+        assertEquals(listOf("api", "utils", "web"), body["projects"].map { it["name"].asText() })
+        assertEquals(2, body["dependencies"].size())
+    }
+
+    @Test
+    fun `should serve the viewer page`() {
+        val response = get("/")
+
+        assertEquals(200, response.statusCode())
+        assertTrue(response.body().contains("/api/graph"))
+        assertEquals(404, get("/missing").statusCode())
+    }
+
+    private fun get(path: String): HttpResponse<String> {
+        val request = HttpRequest.newBuilder(URI.create("http://127.0.0.1:$port$path")).GET().build()
+        return client.send(request, HttpResponse.BodyHandlers.ofString())
+    }
+
+    private fun graph(names: List<String>, edges: List<Pair<String, String>>): ProjectGraph {
+        val nodes = names.associateWith { name ->
+            ProjectGraphNode(name, "library", ProjectConfiguration(
+                name = name,
+                root = "libs/$name",
+                targets = mapOf("build" to TargetConfiguration(executor = "forge:run-commands"))
+            ))
+        }
+        val dependencies = names.associateWith { name ->
+            edges.filter { it.first == name }.map { ProjectGraphDependency(it.first, it.second, DependencyType.STATIC) }
+        }
+        return ProjectGraph(nodes, dependencies)
+    }
+}
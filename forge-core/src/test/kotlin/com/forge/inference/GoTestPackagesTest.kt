@@ -0,0 +1,193 @@
+package com.forge.inference
+
+import build.bazel.remote.execution.v2.ActionResult
+import com.forge.cache.TaskInputResolver
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import com.forge.execution.ExecutionResults
+import com.forge.execution.TaskGraphBuilder
+import com.forge.execution.TestSummaries
+import com.forge.execution.remote.FakeActionCacheService
+import com.forge.execution.remote.FakeExecutionService
+import com.forge.execution.remote.RemoteExecutionConfig
+import com.forge.execution.remote.RemoteExecutionExecutor
+import com.forge.execution.remote.fakeServices
+import com.forge.graph.TaskStatus
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.writeText
+
+class GoTestPackagesTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private val modulePath = "github.com/example/api-gateway"
+    private val projectRoot = "services/api-gateway"
+    private val execution = FakeExecutionService()
+    private val actionCache = FakeActionCacheService()
+
+    @BeforeEach
+    fun setup() {
+        writeFile("go.mod", "module $modulePath\n\ngo 1.21\n")
+        writeFile("main.go", """
+            package main
+
+            import (
+                "fmt"
+
+                "$modulePath/internal/handlers"
+            )
+
+            func main() { fmt.Println(handlers.Name()) }
+        """.trimIndent())
+        writeFile("main_test.go", "package main\n")
+        writeFile("internal/handlers/handlers.go", """
+            package handlers
+
+            import "$modulePath/internal/util"
+
+            func Name() string { return util.Upper("gateway") }
+        """.trimIndent())
+        writeFile("internal/handlers/handlers_test.go", "package handlers\n")
+        writeFile("internal/util/util.go", "package util\n\nimport \"strings\"\n\nfunc Upper(s string) string { return strings.ToUpper(s) }\n")
+        writeFile("internal/util/util_test.go", "package util\n")
+        writeFile("pkg/other/other.go", "package other\n")
+        writeFile("pkg/other/other_test.go", "package other\n")
+        writeFile("vendor/example.com/dep/dep.go", "package dep\n")
+    }
+
+    @Test
+    fun `should find packages and their internal imports`() {
+        val packages = GoTestPackages.scan(workspaceRoot.resolve(projectRoot), modulePath).associateBy { it.dir }
+
+        assertEquals(setOf(".", "internal/handlers", "internal/util", "pkg/other"), packages.keys)
+        assertEquals(setOf("internal/handlers"), packages["."]?.internalImports)
+        assertEquals(setOf("internal/util"), packages["internal/handlers"]?.internalImports)
+        assertTrue(packages["internal/util"]!!.internalImports.isEmpty())
+    }
+
+    @Test
+    fun `should create a test target per package plus an aggregate`() {
+        val targets = splitTargets()
+
+        val aggregate = targets["test"]!!
+        assertEquals(listOf("test/.", "test/internal/handlers", "test/internal/util", "test/pkg/other"), aggregate.dependsOn)
+        assertEquals(TargetConfiguration.TEST_SUMMARY_EXECUTOR, aggregate.executor)
+        assertFalse(aggregate.cache)
+        assertEquals(listOf("go test ./internal/handlers"), targets["test/internal/handlers"]!!.options["commands"])
+        assertEquals(listOf("go test ."), targets["test/."]!!.options["commands"])
+    }
+
+    @Test
+    fun `should only re-run the edited package`() {
+        val before = inputKeys()
+
+        writeFile("pkg/other/other.go", "package other\n\nfunc Other() {}\n")
+
+        assertEquals(setOf("api-gateway:test/pkg/other"), changedTasks(before, inputKeys()))
+    }
+
+    @Test
+    fun `should re-run packages importing the edited package`() {
+        val before = inputKeys()
+
+        writeFile("internal/util/util.go", "package util\n\nfunc Upper(s string) string { return s }\n")
+
+        assertEquals(
+            setOf("api-gateway:test/.", "api-gateway:test/internal/handlers", "api-gateway:test/internal/util"),
+            changedTasks(before, inputKeys())
+        )
+    }
+
+    @Test
+    fun `should not re-run importers when only a test file changes`() {
+        val before = inputKeys()
+
+        writeFile("internal/util/util_test.go", "package util\n\nimport \"testing\"\n")
+
+        assertEquals(setOf("api-gateway:test/internal/util"), changedTasks(before, inputKeys()))
+    }
+
+    @Test
+    fun `should execute only the edited package and summarize all of them`() {
+        assertTrue(runTests().success)
+        assertEquals(4, execution.executed.size)
+
+        writeFile("pkg/other/other.go", "package other\n\nfunc Other() {}\n")
+        val results = runTests()
+
+        assertEquals(5, execution.executed.size, "Only the edited package should execute again")
+        assertEquals(TaskStatus.COMPLETED, results.results["api-gateway:test/pkg/other"]?.status)
+        listOf("test/.", "test/internal/handlers", "test/internal/util").forEach { target ->
+            assertEquals(TaskStatus.CACHED, results.results["api-gateway:$target"]?.status, target)
+        }
+        val summary = results.results.getValue("api-gateway:test")
+        assertEquals(TaskStatus.COMPLETED, summary.status)
+        assertTrue(summary.output.startsWith("4 package(s): 4 passed (3 cached)"), summary.output)
+    }
+
+    @Test
+    fun `should summarize failed packages when the aggregate does not run`() {
+        execution.result = ActionResult.newBuilder().setExitCode(1).build()
+        val plan = TaskGraphBuilder(projectGraph()).buildTaskGraph("test").getExecutionPlan()
+
+        val results = runTests()
+        val summary = TestSummaries.find(plan, results).single()
+
+        assertNull(results.results["api-gateway:test"], "The aggregate waits for its packages")
+        assertFalse(summary.success)
+        assertEquals("4 package(s): 0 passed, 4 failed", summary.headline())
+    }
+
+    private fun runTests(): ExecutionResults {
+        val projectGraph = projectGraph()
+        val executor = RemoteExecutionExecutor(workspaceRoot, projectGraph, RemoteExecutionConfig(),
+            fakeServices(execution, actionCache = actionCache))
+        return try {
+            executor.execute(TaskGraphBuilder(projectGraph).buildTaskGraph("test").getExecutionPlan())
+        } finally {
+            executor.close()
+        }
+    }
+
+    private fun projectGraph(): ProjectGraph {
+        val project = ProjectConfiguration(name = "api-gateway", root = projectRoot, targets = splitTargets())
+        return ProjectGraph(
+            nodes = mapOf("api-gateway" to ProjectGraphNode("api-gateway", "application", project)),
+            dependencies = mapOf("api-gateway" to emptyList())
+        )
+    }
+
+    private fun splitTargets() = GoTestPackages.splitTestTargets(
+        projectRoot,
+        GoTestPackages.scan(workspaceRoot.resolve(projectRoot), modulePath),
+        "test"
+    )
+
+    private fun inputKeys(): Map<String, String> {
+        val projectGraph = projectGraph()
+        val resolver = TaskInputResolver(workspaceRoot, projectGraph)
+
+        return TaskGraphBuilder(projectGraph).buildTaskGraph("test").tasks.values
+            .filter { it.target.isCacheable() }
+            .associate { it.id to resolver.snapshot(it).key }
+    }
+
+    private fun changedTasks(before: Map<String, String>, after: Map<String, String>): Set<String> {
+        assertEquals(before.keys, after.keys)
+        return before.filter { (taskId, key) -> after[taskId] != key }.keys
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(projectRoot).resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+}
@@ -0,0 +1,70 @@
+package com.forge.inference
+
+import com.forge.core.ProjectConfiguration
+import com.forge.core.TargetConfiguration
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+import java.nio.file.Path
+
+class SourceAnnotationsTest {
+
+    private val projectDir = Path.of("src/test/resources/test-annotation-workspace/services/payments")
+
+    @Test
+    fun `should parse tags and targets from magic comments`() {
+        val annotations = SourceAnnotations.parse(
+            """
+            //forge:tags type:service, team:payments
+            //forge:target smoke go run ./cmd/smoke
+            // forge:tags ignored:not-a-magic-comment
+            package main
+            """.trimIndent()
+        )
+
+        assertEquals(listOf("type:service", "team:payments"), annotations.tags)
+        assertEquals(mapOf("smoke" to "go run ./cmd/smoke"), annotations.targets)
+    }
+
+    @Test
+    fun `should apply annotated tag and target to the inferred project`() {
+        val inferred = ProjectConfiguration(
+            name = "payments",
+            root = "services/payments",
+            tags = listOf("go", "golang"),
+            targets = mapOf("build" to TargetConfiguration(options = mapOf("commands" to listOf("go build ./..."))))
+        )
+
+        val project = SourceAnnotations.scan(projectDir, "go", moduleFile = "go.mod").applyTo(inferred)
+
+        assertTrue(project.hasTag("team:payments"), "Annotated tag should be applied")
+        assertTrue(project.hasTag("type:service"), "Annotated tag should be applied")
+        assertTrue(project.hasTag("go"), "Inferred tags should be kept")
+        assertTrue(project.hasTarget("build"), "Inferred targets should be kept")
+
+        val smoke = project.getTarget("smoke")
+        assertNotNull(smoke, "Annotated target should be added")
+        assertEquals(listOf("go run ./cmd/smoke"), smoke!!.options["commands"])
+        assertEquals("services/payments", smoke.options["cwd"])
+    }
+
+    @Test
+    fun `annotated targets should replace inferred targets with the same name`() {
+        val inferred = ProjectConfiguration(
+            name = "payments",
+            root = "services/payments",
+            targets = mapOf("smoke" to TargetConfiguration(options = mapOf("commands" to listOf("echo inferred"))))
+        )
+
+        val project = ProjectAnnotations(targets = mapOf("smoke" to "go run ./cmd/smoke")).applyTo(inferred)
+
+        assertEquals(listOf("go run ./cmd/smoke"), project.getTarget("smoke")!!.options["commands"])
+    }
+
+    @Test
+    fun `should not apply annotations from a nested module`() {
+        val annotations = SourceAnnotations.scan(projectDir, "go", moduleFile = "go.mod")
+
+        assertEquals(listOf("type:service", "team:payments"), annotations.tags)
+        assertEquals(mapOf("smoke" to "go run ./cmd/smoke"), annotations.targets)
+    }
+}
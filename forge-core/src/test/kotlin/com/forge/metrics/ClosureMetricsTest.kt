@@ -0,0 +1,72 @@
+package com.forge.metrics
+
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+
+class ClosureMetricsTest {
+
+    // edge-proxy -> api-gateway -> logging -> go-utils, and api-gateway -> go-utils directly
+    private val projectGraph = ProjectGraph(
+        nodes = listOf("edge-proxy", "api-gateway", "logging", "go-utils").associateWith { name ->
+            ProjectGraphNode(name, "library", ProjectConfiguration(name = name, root = name))
+        },
+        dependencies = mapOf(
+            "edge-proxy" to listOf(dependency("edge-proxy", "api-gateway")),
+            "api-gateway" to listOf(dependency("api-gateway", "logging"), dependency("api-gateway", "go-utils")),
+            "logging" to listOf(dependency("logging", "go-utils")),
+            "go-utils" to emptyList()
+        )
+    )
+
+    @Test
+    fun `should compute transitive dependency and dependent closures`() {
+        val closures = ClosureMetrics(projectGraph).compute().associateBy { it.project }
+
+        val apiGateway = closures.getValue("api-gateway")
+        assertTrue("go-utils" in apiGateway.dependencies)
+        assertEquals(setOf("go-utils", "logging"), apiGateway.dependencies)
+        assertEquals(setOf("edge-proxy"), apiGateway.dependents)
+
+        assertEquals(3, closures.getValue("edge-proxy").dependencyCount)
+        assertEquals(0, closures.getValue("edge-proxy").dependentCount)
+        assertEquals(0, closures.getValue("go-utils").dependencyCount)
+        assertEquals(3, closures.getValue("go-utils").dependentCount)
+        assertEquals(2, closures.getValue("logging").dependentCount)
+    }
+
+    @Test
+    fun `should sort by the requested count`() {
+        val metrics = ClosureMetrics(projectGraph)
+
+        assertEquals(listOf("edge-proxy", "api-gateway", "logging", "go-utils"),
+            metrics.compute(ClosureSort.DEPENDENCIES).map { it.project })
+        assertEquals(listOf("go-utils", "logging", "api-gateway", "edge-proxy"),
+            metrics.compute(ClosureSort.DEPENDENTS).map { it.project })
+        assertEquals(listOf("api-gateway", "edge-proxy", "go-utils", "logging"),
+            metrics.compute(ClosureSort.NAME).map { it.project })
+    }
+
+    @Test
+    fun `should not count a project in its own closure when dependencies form a cycle`() {
+        val cyclic = ProjectGraph(
+            nodes = projectGraph.nodes.filterKeys { it == "logging" || it == "go-utils" },
+            dependencies = mapOf(
+                "logging" to listOf(dependency("logging", "go-utils")),
+                "go-utils" to listOf(dependency("go-utils", "logging"))
+            )
+        )
+
+        val closures = ClosureMetrics(cyclic).compute().associateBy { it.project }
+
+        assertEquals(setOf("go-utils"), closures.getValue("logging").dependencies)
+        assertEquals(setOf("go-utils"), closures.getValue("logging").dependents)
+    }
+
+    private fun dependency(source: String, target: String) =
+        ProjectGraphDependency(source, target, DependencyType.STATIC)
+}
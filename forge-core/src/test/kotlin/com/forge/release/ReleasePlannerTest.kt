@@ -0,0 +1,111 @@
+package com.forge.release
+
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.writeText
+
+class ReleasePlannerTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private lateinit var planner: ReleasePlanner
+
+    @BeforeEach
+    fun setup() {
+        writeFile("libs/go-utils/go.mod", "module github.com/example/go-utils\n")
+        writeFile("libs/go-utils/VERSION", "1.4.0\n")
+        writeFile("libs/shared-lib/go.mod", "module github.com/example/shared-lib/v2\n")
+        writeFile("services/api-gateway/go.mod", "module github.com/example/api-gateway\n")
+        writeFile("services/api-gateway/VERSION", "v0.9.1\n")
+
+        val projectGraph = ProjectGraph(
+            nodes = mapOf(
+                "api-gateway" to project("api-gateway", "services/api-gateway", listOf("releasable")),
+                "go-utils" to project("go-utils", "libs/go-utils", listOf("releasable")),
+                "shared-lib" to project("shared-lib", "libs/shared-lib", listOf("releasable")),
+                "e2e" to project("e2e", "apps/e2e", emptyList())
+            ),
+            dependencies = mapOf(
+                "api-gateway" to listOf(
+                    ProjectGraphDependency("api-gateway", "shared-lib", DependencyType.STATIC),
+                    ProjectGraphDependency("api-gateway", "e2e", DependencyType.IMPLICIT)
+                ),
+                "shared-lib" to listOf(ProjectGraphDependency("shared-lib", "go-utils", DependencyType.STATIC)),
+                "go-utils" to emptyList(),
+                "e2e" to emptyList()
+            )
+        )
+        planner = ReleasePlanner(workspaceRoot, projectGraph, VersionResolver(workspaceRoot) { emptyList() })
+    }
+
+    @Test
+    fun `should order libraries before dependent services`() {
+        val plan = planner.plan("publish")
+
+        assertEquals(listOf("go-utils", "shared-lib", "api-gateway"), plan.projectNames())
+        assertEquals(listOf("go-utils", "shared-lib"), plan.entries.last().dependsOn)
+    }
+
+    @Test
+    fun `should only include releasable projects`() {
+        val plan = planner.plan("publish")
+
+        assertFalse(plan.projectNames().contains("e2e"), "Untagged projects should not be released")
+    }
+
+    @Test
+    fun `should extract versions from VERSION files and go mod`() {
+        val versions = planner.plan("publish").entries.associate { it.project to it.version }
+
+        assertEquals("1.4.0", versions["go-utils"])
+        assertEquals("0.9.1", versions["api-gateway"])
+        assertEquals("2.0.0", versions["shared-lib"])
+    }
+
+    @Test
+    fun `should report disagreeing version sources on the plan entry`() {
+        writeFile("libs/shared-lib/VERSION", "3.1.0\n")
+
+        val entries = planner.plan("publish").entries.associateBy { it.project }
+
+        assertEquals("3.1.0", entries.getValue("shared-lib").version)
+        assertEquals(1, entries.getValue("shared-lib").warnings.size)
+        assertTrue(entries.getValue("shared-lib").warnings.single().contains("module path=2.0.0"),
+            entries.getValue("shared-lib").warnings.single())
+        assertTrue(entries.getValue("go-utils").warnings.isEmpty())
+    }
+
+    @Test
+    fun `should skip projects without the release target`() {
+        val plan = planner.plan("deploy")
+
+        assertTrue(plan.isEmpty())
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+
+    private fun project(name: String, root: String, tags: List<String>): ProjectGraphNode {
+        val config = ProjectConfiguration(
+            name = name,
+            root = root,
+            tags = tags,
+            targets = mapOf("publish" to TargetConfiguration(options = mapOf("commands" to listOf("echo publish"))))
+        )
+        return ProjectGraphNode(name, "library", config)
+    }
+}
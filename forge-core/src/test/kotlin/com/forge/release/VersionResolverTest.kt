@@ -0,0 +1,96 @@
+package com.forge.release
+
+import com.forge.core.ProjectConfiguration
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.writeText
+
+class VersionResolverTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    private val project = ProjectConfiguration(name = "go-utils", root = "libs/go-utils")
+
+    @Test
+    fun `should read version from VERSION file`() {
+        writeFile("libs/go-utils/VERSION", "v1.2.3\n")
+
+        val resolved = VersionResolver(workspaceRoot) { emptyList() }.resolve(project)
+
+        assertEquals("1.2.3", resolved.version)
+        assertEquals(VersionSource.VERSION_FILE, resolved.source)
+        assertTrue(resolved.warnings.isEmpty())
+    }
+
+    @Test
+    fun `should read highest matching git tag`() {
+        val tags = listOf("go-utils/v1.0.0", "go-utils/v1.10.0", "go-utils/v1.9.2", "api-gateway/v3.0.0", "v9.9.9")
+
+        val resolved = VersionResolver(workspaceRoot) { tags }.resolve(project)
+
+        assertEquals("1.10.0", resolved.version)
+        assertEquals(VersionSource.GIT_TAG, resolved.source)
+    }
+
+    @Test
+    fun `should read major version from module path suffix`() {
+        writeFile("libs/go-utils/go.mod", "module github.com/example/go-utils/v2\n\ngo 1.21\n")
+
+        val resolved = VersionResolver(workspaceRoot) { emptyList() }.resolve(project)
+
+        assertEquals("2.0.0", resolved.version)
+        assertEquals(VersionSource.MODULE_PATH, resolved.source)
+    }
+
+    @Test
+    fun `should prefer explicit configuration version`() {
+        writeFile("libs/go-utils/VERSION", "1.2.3\n")
+
+        val resolved = VersionResolver(workspaceRoot) { emptyList() }.resolve(project.copy(version = "1.2.3"))
+
+        assertEquals(VersionSource.CONFIG, resolved.source)
+        assertTrue(resolved.warnings.isEmpty())
+    }
+
+    @Test
+    fun `should warn and apply precedence when sources disagree`() {
+        writeFile("libs/go-utils/VERSION", "2.1.0\n")
+        writeFile("libs/go-utils/go.mod", "module github.com/example/go-utils/v2\n")
+
+        val resolved = VersionResolver(workspaceRoot) { listOf("go-utils/v2.0.5") }.resolve(project)
+
+        assertEquals("2.1.0", resolved.version)
+        assertEquals(VersionSource.VERSION_FILE, resolved.source)
+        assertEquals(3, resolved.candidates.size)
+        assertEquals(1, resolved.warnings.size)
+        assertTrue(resolved.warnings.first().contains("git tag=2.0.5"), resolved.warnings.first())
+    }
+
+    @Test
+    fun `module path should only need to agree on the major version`() {
+        writeFile("libs/go-utils/VERSION", "2.1.0\n")
+        writeFile("libs/go-utils/go.mod", "module github.com/example/go-utils/v2\n")
+
+        val resolved = VersionResolver(workspaceRoot) { emptyList() }.resolve(project)
+
+        assertTrue(resolved.warnings.isEmpty())
+    }
+
+    @Test
+    fun `should return no version when no source exists`() {
+        val resolved = VersionResolver(workspaceRoot) { emptyList() }.resolve(project)
+
+        assertNull(resolved.version)
+        assertNull(resolved.source)
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+}
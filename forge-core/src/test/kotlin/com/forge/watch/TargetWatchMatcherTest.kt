@@ -0,0 +1,90 @@
+package com.forge.watch
+
+import com.forge.cache.TaskInputResolver
+import com.forge.core.DependencyType
+import com.forge.core.ProjectConfiguration
+import com.forge.core.ProjectGraph
+import com.forge.core.ProjectGraphDependency
+import com.forge.core.ProjectGraphNode
+import com.forge.core.TargetConfiguration
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import java.nio.file.Path
+
+class TargetWatchMatcherTest {
+
+    private lateinit var matcher: TargetWatchMatcher
+
+    @BeforeEach
+    fun setup() {
+        val projectGraph = ProjectGraph(
+            nodes = mapOf(
+                "api-gateway" to goProject("api-gateway", "services/api-gateway"),
+                "go-utils" to goProject("go-utils", "libs/go-utils")
+            ),
+            dependencies = mapOf(
+                "api-gateway" to listOf(ProjectGraphDependency("api-gateway", "go-utils", DependencyType.STATIC)),
+                "go-utils" to emptyList()
+            )
+        )
+
+        matcher = TargetWatchMatcher(
+            projectGraph = projectGraph,
+            inputResolver = TaskInputResolver(Path.of("/workspace"), projectGraph),
+            targetNames = setOf("build", "test")
+        )
+    }
+
+    @Test
+    fun `should re-run only test when a test file changes`() {
+        val matched = matcher.matchByTarget(listOf("services/api-gateway/handler_test.go"))
+
+        assertEquals(mapOf("test" to listOf("api-gateway")), matched)
+    }
+
+    @Test
+    fun `should re-run build and test when a source file changes`() {
+        val matched = matcher.matchByTarget(listOf("services/api-gateway/main.go"))
+
+        assertEquals(
+            mapOf("build" to listOf("api-gateway"), "test" to listOf("api-gateway")),
+            matched
+        )
+    }
+
+    @Test
+    fun `should re-run dependents through dependency inputs`() {
+        val matched = matcher.matchByTarget(listOf("libs/go-utils/utils.go"))
+
+        assertEquals(listOf("api-gateway", "go-utils"), matched["build"])
+    }
+
+    @Test
+    fun `should ignore files outside any target inputs`() {
+        val matched = matcher.matchByTarget(listOf("docs/architecture.md"))
+
+        assertTrue(matched.isEmpty(), "No targets should re-run for unrelated files")
+    }
+
+    private fun goProject(name: String, root: String): ProjectGraphNode {
+        val targets = mapOf(
+            "build" to TargetConfiguration(
+                executor = "forge:run-commands",
+                options = mapOf("commands" to listOf("go build ./...")),
+                inputs = listOf(
+                    "{projectRoot}/**/*.go",
+                    "^default",
+                    "{projectRoot}/go.mod",
+                    "!{projectRoot}/**/*_test.go"
+                )
+            ),
+            "test" to TargetConfiguration(
+                executor = "forge:run-commands",
+                options = mapOf("commands" to listOf("go test ./...")),
+                inputs = listOf("{projectRoot}/**/*.go", "{projectRoot}/**/*_test.go")
+            )
+        )
+        return ProjectGraphNode(name, "library", ProjectConfiguration(name = name, root = root, targets = targets))
+    }
+}
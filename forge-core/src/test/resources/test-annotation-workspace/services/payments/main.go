@@ -0,0 +1,9 @@
+//forge:tags type:service,team:payments
+//forge:target smoke go run ./cmd/smoke
+package main
+
+import "log"
+
+func main() {
+    log.Println("Starting payments service")
+}
@@ -0,0 +1,9 @@
+//forge:tags type:tool,team:platform
+//forge:target migrate go run .
+package main
+
+import "fmt"
+
+func main() {
+    fmt.Println("migrating payments schema")
+}
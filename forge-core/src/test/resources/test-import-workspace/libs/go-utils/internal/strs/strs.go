@@ -0,0 +1,11 @@
+package strs
+
+import "strings"
+
+// Title upper-cases the first letter of s
+func Title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
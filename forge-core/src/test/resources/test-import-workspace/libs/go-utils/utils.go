@@ -0,0 +1,6 @@
+package utils
+
+import "github.com/example/go-utils/internal/strs"
+
+// Title upper-cases the first letter of s
+func Title(s string) string { return strs.Title(s) }
@@ -0,0 +1,7 @@
+module github.com/example/payments
+
+go 1.21
+
+require github.com/example/go-utils v0.0.0
+
+replace github.com/example/go-utils => ../../libs/go-utils
@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"github.com/example/go-utils/internal/strs"
+	"github.com/example/orders/internal/db"
+	"github.com/example/payments/internal/cache"
+)
+
+// Name returns the handler name
+func Name() string {
+	return strs.Title(db.Driver + cache.Prefix)
+}
@@ -0,0 +1,13 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/example/payments/handlers"
+)
+
+func TestName(t *testing.T) {
+	if handlers.Name() == "" {
+		t.Fatal("expected a name")
+	}
+}
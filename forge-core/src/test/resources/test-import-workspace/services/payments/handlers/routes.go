@@ -0,0 +1,4 @@
+package handler
+
+// Routes lists the HTTP routes served by the handlers
+var Routes = []string{"/pay", "/refund"}
@@ -0,0 +1,4 @@
+package db // import "github.com/example/orders/internal/db"
+
+// Driver is the SQL driver used by the service
+const Driver = "postgres"
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"fmt"
+
+	utils "github.com/example/go-utils"
+	"github.com/example/payments/handlers"
+	"github.com/example/payments/internal/db"
+)
+
+func main() {
+	fmt.Println(utils.Title(handlers.Name()), db.Driver)
+}
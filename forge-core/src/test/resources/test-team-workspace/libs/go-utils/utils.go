@@ -0,0 +1,4 @@
+//forge:tags team:platform
+package utils
+
+func Noop() {}
@@ -0,0 +1,3 @@
+module github.com/example/api-gateway
+
+go 1.21
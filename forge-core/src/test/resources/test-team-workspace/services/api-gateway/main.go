@@ -0,0 +1,4 @@
+//forge:tags type:service,team:edge
+package main
+
+func main() {}
@@ -0,0 +1,3 @@
+module github.com/example/payments-worker
+
+go 1.21
@@ -0,0 +1,4 @@
+//forge:tags type:worker,team:payments
+package main
+
+func main() {}
@@ -0,0 +1,4 @@
+//forge:tags type:service,team:payments
+package main
+
+func main() {}
@@ -3,15 +3,18 @@ package com.forge.daemon
 import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
 import com.fasterxml.jackson.module.kotlin.readValue
 import com.forge.core.WorkspaceConfiguration
-import com.forge.discovery.ProjectDiscovery
+import com.forge.core.ProjectGraph
+import com.forge.discovery.IncrementalProjectGraph
 import com.forge.execution.ExecutorFactory
 import com.forge.graph.Task
 import com.forge.graph.TaskExecutionPlan
+import com.forge.watch.WorkspaceWatcher
 import kotlinx.coroutines.*
 import org.slf4j.LoggerFactory
 import java.io.*
 import java.nio.file.Path
 import java.nio.file.Paths
+import java.util.concurrent.ConcurrentHashMap
 import java.util.concurrent.atomic.AtomicBoolean
 import java.util.concurrent.atomic.AtomicInteger
 import kotlin.io.path.exists
@@ -51,6 +54,8 @@ class DaemonServer {
     private val mapper = jacksonObjectMapper()
     private val running = AtomicBoolean(false)
     private val requestId = AtomicInteger(0)
+    private val projectGraphs = ConcurrentHashMap<Path, IncrementalProjectGraph>()
+    private val watchers = ConcurrentHashMap<Path, WorkspaceWatcher>()
     
     suspend fun start() = withContext(Dispatchers.IO) {
         if (running.get()) {
@@ -128,8 +133,7 @@ class DaemonServer {
                     val workspaceRoot = params["workspaceRoot"] as? String ?: Paths.get("").toAbsolutePath().toString()
                     val format = params["format"] as? String ?: "text"
                     
-                    val discovery = createProjectDiscovery(workspaceRoot)
-                    val projectGraph = discovery.discoverProjects()
+                    val projectGraph = projectGraphFor(workspaceRoot)
                     val projects = projectGraph.nodes.values
                     if (format == "json") {
                         projects.map { 
@@ -151,8 +155,7 @@ class DaemonServer {
                     val projectName = params["projectName"] as? String ?: throw IllegalArgumentException("projectName required")
                     val format = params["format"] as? String ?: "text"
                     
-                    val discovery = createProjectDiscovery(workspaceRoot)
-                    val projectGraph = discovery.discoverProjects()
+                    val projectGraph = projectGraphFor(workspaceRoot)
                     val project = projectGraph.nodes[projectName] ?: throw RuntimeException("Project '$projectName' not found")
                     
                     if (format == "json") {
@@ -199,8 +202,7 @@ class DaemonServer {
                     val all = params["all"] as? Boolean ?: false
                     val dryRun = params["dryRun"] as? Boolean ?: false
                     
-                    val discovery = createProjectDiscovery(workspaceRoot)
-                    val projectGraph = discovery.discoverProjects()
+                    val projectGraph = projectGraphFor(workspaceRoot)
                     
                     if (dryRun) {
                         val projects = if (all) projectGraph.nodes.values else {
@@ -224,8 +226,7 @@ class DaemonServer {
                     val workspaceRoot = params["workspaceRoot"] as? String ?: Paths.get("").toAbsolutePath().toString()
                     val format = params["format"] as? String ?: "text"
                     
-                    val discovery = createProjectDiscovery(workspaceRoot)
-                    val projectGraph = discovery.discoverProjects()
+                    val projectGraph = projectGraphFor(workspaceRoot)
                     
                     if (format == "json") {
                         // Convert dependencies map to a simple format for JSON
@@ -256,9 +257,36 @@ class DaemonServer {
         }
     }
     
-    private fun createProjectDiscovery(workspaceRoot: String): ProjectDiscovery {
-        val workspaceRootPath = Paths.get(workspaceRoot)
-        return ProjectDiscovery(workspaceRootPath)
+    /**
+     * The cached project graph of a workspace. The first request builds it and starts a watcher
+     * that updates it incrementally as files change, so later requests skip discovery entirely.
+     */
+    private fun projectGraphFor(workspaceRoot: String): ProjectGraph {
+        val root = Paths.get(workspaceRoot).toAbsolutePath().normalize()
+        val incremental = projectGraphs.computeIfAbsent(root) { path ->
+            IncrementalProjectGraph(path).also { graph ->
+                graph.build()
+                watchWorkspace(path, graph)
+            }
+        }
+        return synchronized(incremental) { incremental.graph }
+    }
+    
+    private fun watchWorkspace(workspaceRoot: Path, incremental: IncrementalProjectGraph) {
+        val watcher = WorkspaceWatcher(workspaceRoot)
+        watchers[workspaceRoot] = watcher
+        
+        Thread({
+            watcher.watch { changedFiles ->
+                try {
+                    synchronized(incremental) { incremental.update(changedFiles) }
+                } catch (e: Exception) {
+                    logger.warn("Failed to update project graph for $workspaceRoot: ${e.message}")
+                }
+            }
+        }, "forge-graph-watcher").apply { isDaemon = true }.start()
+        
+        logger.info("Caching project graph for $workspaceRoot")
     }
     
     private fun loadWorkspaceConfiguration(workspaceRoot: Path): WorkspaceConfiguration {
@@ -289,8 +317,7 @@ class DaemonServer {
     ): String = withContext(Dispatchers.IO) {
         try {
             val path = Paths.get(workspaceRoot)
-            val discovery = ProjectDiscovery(path)
-            val projectGraph = discovery.discoverProjects()
+            val projectGraph = projectGraphFor(workspaceRoot)
             
             // Find the project
             val project = projectGraph.nodes[projectName]
@@ -360,6 +387,9 @@ class DaemonServer {
         if (!running.get()) return
         
         running.set(false)
+        watchers.values.forEach { it.close() }
+        watchers.clear()
+        projectGraphs.clear()
         logger.info("Daemon server stopped")
     }
 }
\ No newline at end of file
@@ -56,17 +56,13 @@ class DockerForgePlugin : ForgePlugin {
         val projects = mutableMapOf<String, ProjectConfiguration>()
         
         configFiles.forEach { configFile ->
-            try {
-                val dockerfilePath = Path.of(configFile)
-                if (dockerfilePath.exists()) {
-                    val project = inferProjectFromDockerfile(dockerfilePath, opts, context)
-                    if (project != null) {
-                        projects[project.name] = project
-                        logger.debug("Inferred project '${project.name}' from $configFile")
-                    }
+            val dockerfilePath = Path.of(configFile)
+            if (dockerfilePath.exists()) {
+                val project = inferProjectFromDockerfile(dockerfilePath, opts, context)
+                if (project != null) {
+                    projects[project.name] = project
+                    logger.debug("Inferred project '${project.name}' from $configFile")
                 }
-            } catch (e: Exception) {
-                logger.warn("Failed to process Dockerfile at $configFile: ${e.message}")
             }
         }
         
@@ -83,7 +79,7 @@ class DockerForgePlugin : ForgePlugin {
         // They depend on base images and external resources, not other workspace projects
         // However, we could analyze docker-compose.yml files for service dependencies
         
-        context.projects.values.forEach { project ->
+        context.sourceProjects().forEach { project ->
             val projectDir = context.workspaceRoot.resolve(project.root)
             
             // Check for docker-compose files that might indicate service dependencies
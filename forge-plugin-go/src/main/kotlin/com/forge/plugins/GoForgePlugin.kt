@@ -8,6 +8,9 @@ import com.forge.inference.CreateNodesContext
 import com.forge.inference.CreateNodesResult
 import com.forge.inference.CreateDependenciesContext
 import com.forge.inference.RawProjectGraphDependency
+import com.forge.inference.GoSource
+import com.forge.inference.GoTestPackages
+import com.forge.inference.SourceAnnotations
 import com.forge.core.DependencyType
 import com.forge.plugin.ForgePlugin
 import com.forge.plugin.PluginMetadata
@@ -22,7 +25,8 @@ import kotlin.io.path.*
 data class GoPluginOptions(
     val buildTargetName: String = "build",
     val testTargetName: String = "test",
-    val lintTargetName: String = "lint"
+    val lintTargetName: String = "lint",
+    val splitTestsByPackage: Boolean = false
 )
 
 /**
@@ -54,19 +58,14 @@ class GoForgePlugin : ForgePlugin {
     ): CreateNodesResult {
         val opts = parseOptions(options)
         val projects = mutableMapOf<String, ProjectConfiguration>()
-        
         configFiles.forEach { configFile ->
-            try {
-                val goModPath = Path.of(configFile)
-                if (goModPath.exists()) {
-                    val project = inferProjectFromGoMod(goModPath, opts, context)
-                    if (project != null) {
-                        projects[project.name] = project
-                        logger.debug("Inferred project '${project.name}' from $configFile")
-                    }
+            val goModPath = Path.of(configFile)
+            if (goModPath.exists()) {
+                val project = inferProjectFromGoMod(goModPath, opts, context)
+                if (project != null) {
+                    projects[project.name] = project
+                    logger.debug("Inferred project '${project.name}' from $configFile")
                 }
-            } catch (e: Exception) {
-                logger.warn("Failed to process go.mod at $configFile: ${e.message}")
             }
         }
         
@@ -84,7 +83,7 @@ class GoForgePlugin : ForgePlugin {
             val goModPath = context.workspaceRoot.resolve(projectConfig.root).resolve("go.mod")
             if (goModPath.exists()) {
                 try {
-                    val modulePath = parseGoModulePath(goModPath.readText())
+                    val modulePath = GoSource.modulePath(goModPath.readText())
                     if (modulePath != null) {
                         modulePath to projectName
                     } else null
@@ -96,7 +95,7 @@ class GoForgePlugin : ForgePlugin {
         }.toMap()
         
         // Process each Go project to find internal dependencies
-        context.projects.values.forEach { project ->
+        context.sourceProjects().forEach { project ->
             val goModPath = context.workspaceRoot.resolve(project.root).resolve("go.mod")
             if (goModPath.exists()) {
                 try {
@@ -130,7 +129,8 @@ class GoForgePlugin : ForgePlugin {
                 GoPluginOptions(
                     buildTargetName = map["buildTargetName"] as? String ?: defaultOptions.buildTargetName,
                     testTargetName = map["testTargetName"] as? String ?: defaultOptions.testTargetName,
-                    lintTargetName = map["lintTargetName"] as? String ?: defaultOptions.lintTargetName
+                    lintTargetName = map["lintTargetName"] as? String ?: defaultOptions.lintTargetName,
+                    splitTestsByPackage = map["splitTestsByPackage"] as? Boolean ?: defaultOptions.splitTestsByPackage
                 )
             }
             else -> throw IllegalArgumentException("Invalid options type: ${options::class}")
@@ -143,7 +143,8 @@ class GoForgePlugin : ForgePlugin {
         context: CreateNodesContext
     ): ProjectConfiguration? {
         val goModContent = goModPath.readText()
-        val modulePath = parseGoModulePath(goModContent) ?: return null
+        val modulePath = GoSource.modulePath(goModContent)
+            ?: throw IllegalArgumentException("go.mod has no module directive")
         
         val projectRoot = context.workspaceRoot.relativize(goModPath.parent).toString()
         
@@ -152,9 +153,9 @@ class GoForgePlugin : ForgePlugin {
         
         val projectType = inferProjectType(goModPath.parent)
         val tags = extractTags(goModPath.parent)
-        val targets = inferTargets(options, projectRoot)
+        val targets = inferTargets(options, projectRoot, goModPath.parent, modulePath)
         
-        return ProjectConfiguration(
+        val project = ProjectConfiguration(
             name = projectName,
             root = projectRoot,
             sourceRoot = projectRoot,
@@ -162,25 +163,9 @@ class GoForgePlugin : ForgePlugin {
             tags = tags,
             targets = targets
         )
-    }
-    
-    private fun parseGoModulePath(goModContent: String): String? {
-        return try {
-            val lines = goModContent.lines()
-            val moduleLineRegex = Regex("""module\s+(.+)""")
-            
-            lines.forEach { line ->
-                val trimmedLine = line.trim()
-                val match = moduleLineRegex.find(trimmedLine)
-                if (match != null) {
-                    return match.groupValues[1].trim()
-                }
-            }
-            null
-        } catch (e: Exception) {
-            logger.warn("Failed to parse go.mod module path: ${e.message}")
-            null
-        }
+        
+        // Fold in //forge:tags and //forge:target annotations declared in the Go sources of this module
+        return SourceAnnotations.scan(goModPath.parent, "go", moduleFile = "go.mod").applyTo(project)
     }
     
     private fun inferProjectType(projectDir: Path): String {
@@ -241,7 +226,9 @@ class GoForgePlugin : ForgePlugin {
     
     private fun inferTargets(
         options: GoPluginOptions,
-        projectRoot: String
+        projectRoot: String,
+        projectDir: Path,
+        modulePath: String
     ): Map<String, TargetConfiguration> {
         val targets = mutableMapOf<String, TargetConfiguration>()
         
@@ -257,7 +244,8 @@ class GoForgePlugin : ForgePlugin {
                 "^default",
                 "{projectRoot}/**/*.go",
                 "{projectRoot}/go.mod",
-                "{projectRoot}/go.sum"
+                "{projectRoot}/go.sum",
+                "!{projectRoot}/**/*_test.go"
             ),
             outputs = listOf("{projectRoot}/bin/**/*"),
             cache = true
@@ -281,6 +269,14 @@ class GoForgePlugin : ForgePlugin {
             dependsOn = listOf()
         )
         
+        // Per-package test targets, so only packages affected by an edit re-run
+        if (options.splitTestsByPackage) {
+            val packages = GoTestPackages.scan(projectDir, modulePath)
+            if (packages.any { it.hasTests }) {
+                targets.putAll(GoTestPackages.splitTestTargets(projectRoot, packages, options.testTargetName))
+            }
+        }
+        
         // Lint target
         targets[options.lintTargetName] = TargetConfiguration(
             executor = "forge:run-commands",
@@ -0,0 +1,105 @@
+package com.forge.plugins
+
+import com.forge.core.ProjectGraph
+import com.forge.core.TargetConfiguration
+import com.forge.discovery.ProjectDiscovery
+import com.forge.inference.InferenceEngine
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.writeText
+
+class GoForgePluginTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    @Test
+    fun `should apply annotations to the module that declares them`() {
+        writeFile("services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+        writeFile("services/payments/main.go", "//forge:tags team:payments\n//forge:target smoke go run ./cmd/smoke\npackage main\n")
+        writeFile("services/payments/tools/migrate/go.mod", "module github.com/example/migrate\n\ngo 1.21\n")
+        writeFile("services/payments/tools/migrate/main.go", "//forge:tags team:platform\n//forge:target migrate go run .\npackage main\n")
+
+        val graph = discover()
+        val payments = graph.getProject("payments")!!.data
+        val migrate = graph.getProject("migrate")!!.data
+
+        assertTrue(payments.hasTag("team:payments"))
+        assertFalse(payments.hasTag("team:platform"), "Tags of the nested module must not leak into its parent")
+        assertTrue(payments.hasTarget("smoke"))
+        assertFalse(payments.hasTarget("migrate"), "Targets of the nested module must not leak into its parent")
+        assertTrue(migrate.hasTag("team:platform"))
+        assertTrue(migrate.hasTarget("migrate"))
+    }
+
+    @Test
+    fun `should prefer a project json target over an annotated one`() {
+        writeFile("services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+        writeFile("services/payments/main.go", "//forge:target smoke go run ./cmd/smoke\npackage main\n")
+        writeFile("services/payments/project.json", """
+            {
+              "name": "payments",
+              "targets": {
+                "smoke": {
+                  "executor": "forge:run-commands",
+                  "options": { "commands": ["./scripts/smoke.sh"] }
+                }
+              }
+            }
+        """.trimIndent())
+
+        val payments = discover().getProject("payments")!!.data
+
+        assertEquals(listOf("./scripts/smoke.sh"), payments.getTarget("smoke")!!.options["commands"])
+        assertTrue(payments.hasTarget("build"), "Inferred targets should still be added")
+    }
+
+    @Test
+    fun `should split tests by package when forge json enables it`() {
+        writeFile("forge.json", """
+            {
+              "plugins": [
+                { "plugin": "@forge/go", "options": { "splitTestsByPackage": true } }
+              ]
+            }
+        """.trimIndent())
+        writeFile("services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+        writeFile("services/payments/main.go", "package main\n")
+        writeFile("services/payments/main_test.go", "package main\n")
+        writeFile("services/payments/internal/ledger/ledger.go", "package ledger\n")
+        writeFile("services/payments/internal/ledger/ledger_test.go", "package ledger\n")
+
+        val payments = discover().getProject("payments")!!.data
+
+        assertTrue(payments.hasTarget("test/."))
+        assertTrue(payments.hasTarget("test/internal/ledger"))
+        val test = payments.getTarget("test")!!
+        assertEquals(TargetConfiguration.TEST_SUMMARY_EXECUTOR, test.executor)
+        assertEquals(listOf("test/.", "test/internal/ledger"), test.dependsOn)
+    }
+
+    @Test
+    fun `should keep a single test target by default`() {
+        writeFile("services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+        writeFile("services/payments/internal/ledger/ledger_test.go", "package ledger\n")
+
+        val payments = discover().getProject("payments")!!.data
+
+        assertFalse(payments.hasTarget("test/internal/ledger"))
+        assertNotEquals(TargetConfiguration.TEST_SUMMARY_EXECUTOR, payments.getTarget("test")!!.executor)
+    }
+
+    private fun discover(): ProjectGraph {
+        val engine = InferenceEngine(plugins = listOf(GoForgePlugin()))
+        return ProjectDiscovery(workspaceRoot, enableInference = true, inferenceEngine = engine).discoverProjects()
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+}
@@ -0,0 +1,87 @@
+package com.forge.plugins
+
+import com.forge.core.ProjectGraph
+import com.forge.discovery.IncrementalProjectGraph
+import com.forge.discovery.ProjectDiscovery
+import com.forge.inference.InferenceEngine
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import kotlin.io.path.deleteExisting
+import kotlin.io.path.writeText
+
+class GoIncrementalProjectGraphTest {
+
+    @TempDir
+    lateinit var workspaceRoot: Path
+
+    @Test
+    fun `should pick up an edited annotation`() {
+        writeFile("libs/go-utils/go.mod", "module github.com/example/go-utils\n\ngo 1.21\n")
+        writeFile("libs/go-utils/utils.go", "package utils\n")
+        writeFile("services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+        writeFile("services/payments/main.go", "//forge:tags team:payments\n//forge:target smoke go run ./cmd/smoke\npackage main\n")
+        val incremental = build()
+
+        writeFile("services/payments/main.go", "//forge:tags team:checkout\n//forge:target smoke go run ./cmd/canary\npackage main\n")
+        val payments = incremental.update(listOf("services/payments/main.go")).getProject("payments")!!.data
+
+        assertTrue(payments.hasTag("team:checkout"))
+        assertFalse(payments.hasTag("team:payments"))
+        assertEquals(listOf("go run ./cmd/canary"), payments.getTarget("smoke")!!.options["commands"])
+        assertMatchesFullDiscovery(incremental)
+    }
+
+    @Test
+    fun `should add and drop targets annotated in other source files`() {
+        writeFile("services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+        writeFile("services/payments/main.go", "package main\n")
+        val incremental = build()
+
+        writeFile("services/payments/tools.go", "//forge:target migrate go run ./cmd/migrate\npackage main\n")
+        assertTrue(incremental.update(listOf("services/payments/tools.go")).getProject("payments")!!.data.hasTarget("migrate"))
+        assertMatchesFullDiscovery(incremental)
+
+        workspaceRoot.resolve("services/payments/tools.go").deleteExisting()
+        assertFalse(incremental.update(listOf("services/payments/tools.go")).getProject("payments")!!.data.hasTarget("migrate"))
+        assertMatchesFullDiscovery(incremental)
+    }
+
+    @Test
+    fun `should re-infer a module at the workspace root`() {
+        writeFile("go.mod", "module github.com/example/monolith\n\ngo 1.21\n")
+        writeFile("main.go", "package main\n")
+        val incremental = build()
+
+        writeFile("main.go", "//forge:tags team:platform\npackage main\n")
+        val monolith = incremental.update(listOf("main.go")).getProject("monolith")!!.data
+
+        assertTrue(monolith.hasTag("team:platform"))
+        assertMatchesFullDiscovery(incremental)
+    }
+
+    private fun build(): IncrementalProjectGraph {
+        val incremental = IncrementalProjectGraph(workspaceRoot, InferenceEngine(plugins = listOf(GoForgePlugin())))
+        incremental.build()
+        return incremental
+    }
+
+    private fun assertMatchesFullDiscovery(incremental: IncrementalProjectGraph) {
+        val full = discover()
+        assertEquals(full.nodes, incremental.graph.nodes)
+        assertEquals(full.dependencies, incremental.graph.dependencies)
+    }
+
+    private fun discover(): ProjectGraph {
+        val engine = InferenceEngine(plugins = listOf(GoForgePlugin()))
+        return ProjectDiscovery(workspaceRoot, enableInference = true, inferenceEngine = engine).discoverProjects()
+    }
+
+    private fun writeFile(relativePath: String, content: String) {
+        val path = workspaceRoot.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+}
@@ -0,0 +1,66 @@
+package com.forge.plugins
+
+import com.forge.discovery.ProjectDiscovery
+import com.forge.inference.InferenceEngine
+import org.junit.jupiter.api.Assertions.*
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.junit.jupiter.api.io.TempDir
+import java.nio.file.Files
+import java.nio.file.Path
+import java.nio.file.Paths
+import kotlin.io.path.writeText
+
+class PartialInferenceTest {
+
+    private lateinit var workspaceRoot: Path
+
+    @BeforeEach
+    fun setup() {
+        val resourcesPath = this::class.java.classLoader.getResource("test-partial-inference-workspace")?.toURI()
+        assertNotNull(resourcesPath, "Test workspace not found in resources")
+        workspaceRoot = Paths.get(resourcesPath!!)
+    }
+
+    @Test
+    fun `should discover healthy projects when one go mod is malformed`() {
+        val discovery = ProjectDiscovery(workspaceRoot, inferenceEngine = InferenceEngine(plugins = listOf(GoForgePlugin())))
+
+        val projectGraph = discovery.discoverProjects()
+
+        assertEquals(setOf("api-gateway", "go-utils"), projectGraph.nodes.keys)
+        assertEquals(1, discovery.inferenceErrors.size)
+
+        val error = discovery.inferenceErrors.single()
+        assertEquals("com.forge.go", error.source)
+        assertEquals("services/broken/go.mod", error.file)
+        assertEquals("go.mod has no module directive", error.message)
+    }
+
+    @Test
+    fun `should keep the inferred targets of healthy projects`() {
+        val result = InferenceEngine(plugins = listOf(GoForgePlugin())).runInference(workspaceRoot)
+
+        val apiGateway = result.projects.getValue("api-gateway")
+        assertTrue(apiGateway.hasTarget("build"))
+        assertTrue(apiGateway.hasTarget("test"))
+        assertEquals(listOf("services/broken/go.mod"), result.errors.map { it.file })
+    }
+
+    @Test
+    fun `should report no errors for a healthy workspace`(@TempDir healthyRoot: Path) {
+        writeFile(healthyRoot, "libs/go-utils/go.mod", "module github.com/example/go-utils\n\ngo 1.21\n")
+        writeFile(healthyRoot, "services/payments/go.mod", "module github.com/example/payments\n\ngo 1.21\n")
+
+        val result = InferenceEngine(plugins = listOf(GoForgePlugin())).runInference(healthyRoot)
+
+        assertEquals(setOf("go-utils", "payments"), result.projects.keys)
+        assertTrue(result.errors.isEmpty())
+    }
+
+    private fun writeFile(root: Path, relativePath: String, content: String) {
+        val path = root.resolve(relativePath)
+        Files.createDirectories(path.parent)
+        path.writeText(content)
+    }
+}
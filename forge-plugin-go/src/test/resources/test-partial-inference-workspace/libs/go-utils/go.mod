@@ -0,0 +1,3 @@
+module github.com/example/go-utils
+
+go 1.21
@@ -0,0 +1,5 @@
+module github.com/example/api-gateway
+
+go 1.21
+
+require github.com/example/go-utils v0.0.0
@@ -0,0 +1,6 @@
+modul github.com/example/broken
+
+go 1.21
+
+require (
+	github.com/example/go-utils
@@ -58,17 +58,13 @@ class JavaScriptForgePlugin : ForgePlugin {
         val projects = mutableMapOf<String, ProjectConfiguration>()
         
         configFiles.forEach { configFile ->
-            try {
-                val packageJsonPath = Path.of(configFile)
-                if (packageJsonPath.exists()) {
-                    val project = inferProjectFromPackageJson(packageJsonPath, opts, context)
-                    if (project != null) {
-                        projects[project.name] = project
-                        logger.debug("Inferred project '${project.name}' from $configFile")
-                    }
+            val packageJsonPath = Path.of(configFile)
+            if (packageJsonPath.exists()) {
+                val project = inferProjectFromPackageJson(packageJsonPath, opts, context)
+                if (project != null) {
+                    projects[project.name] = project
+                    logger.debug("Inferred project '${project.name}' from $configFile")
                 }
-            } catch (e: Exception) {
-                logger.warn("Failed to process package.json at $configFile: ${e.message}")
             }
         }
         
@@ -99,7 +95,7 @@ class JavaScriptForgePlugin : ForgePlugin {
         }.toMap()
         
         // Process each JavaScript project to find internal dependencies
-        context.projects.values.forEach { project ->
+        context.sourceProjects().forEach { project ->
             val packageJsonPath = context.workspaceRoot.resolve(project.root).resolve("package.json")
             if (packageJsonPath.exists()) {
                 try {
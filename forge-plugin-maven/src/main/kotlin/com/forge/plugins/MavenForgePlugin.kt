@@ -58,17 +58,13 @@ class MavenForgePlugin : ForgePlugin {
         val projects = mutableMapOf<String, ProjectConfiguration>()
         
         configFiles.forEach { configFile ->
-            try {
-                val pomPath = Path.of(configFile)
-                if (pomPath.exists()) {
-                    val project = inferProjectFromPom(pomPath, opts, context)
-                    if (project != null) {
-                        projects[project.name] = project
-                        logger.debug("Inferred project '${project.name}' from $configFile")
-                    }
+            val pomPath = Path.of(configFile)
+            if (pomPath.exists()) {
+                val project = inferProjectFromPom(pomPath, opts, context)
+                if (project != null) {
+                    projects[project.name] = project
+                    logger.debug("Inferred project '${project.name}' from $configFile")
                 }
-            } catch (e: Exception) {
-                logger.warn("Failed to process pom.xml at $configFile: ${e.message}")
             }
         }
         
@@ -99,7 +95,7 @@ class MavenForgePlugin : ForgePlugin {
         }.toMap()
         
         // Process each Maven project to find internal dependencies
-        context.projects.values.forEach { project ->
+        context.sourceProjects().forEach { project ->
             val pomPath = context.workspaceRoot.resolve(project.root).resolve("pom.xml")
             if (pomPath.exists()) {
                 try {
@@ -165,14 +161,12 @@ class MavenForgePlugin : ForgePlugin {
         )
     }
     
+    /**
+     * Parse a POM, throwing on malformed XML so the broken module is reported rather than silently dropped
+     */
     private fun parsePom(pomContent: String): Map<String, Any> {
-        return try {
-            @Suppress("UNCHECKED_CAST")
-            xmlMapper.readValue(pomContent, Map::class.java) as Map<String, Any>
-        } catch (e: Exception) {
-            logger.warn("Failed to parse POM XML: ${e.message}")
-            emptyMap()
-        }
+        @Suppress("UNCHECKED_CAST")
+        return xmlMapper.readValue(pomContent, Map::class.java) as Map<String, Any>
     }
     
     private fun inferProjectType(pom: Map<String, Any>, projectDir: Path): String {